@@ -8,13 +8,24 @@ import (
 	"github.com/nyttikord/gomath/lexer"
 	"github.com/nyttikord/gomath/math"
 	"slices"
-	"strconv"
 )
 
 var (
 	termOperators   = []string{"+", "-"}
-	factorOperators = []string{"*", "/"}
+	factorOperators = []string{"*", "/", "%"}
 	expOperators    = []string{"^"}
+	// logicKeywords are the literals used as boolean operators
+	logicKeywords = []string{"and", "or", "not"}
+	// conditionalFunctions are the functions choosing a branch with conditions
+	conditionalFunctions = []string{"if", "piecewise"}
+	// integralFunction is the function binding its variable of integration
+	integralFunction = "integrate"
+	// conversionFunction is the function converting a quantity in a unit
+	conversionFunction = "convert"
+	// degreeKeywords are the postfix literals of an angle measured in degrees, like 30 deg
+	degreeKeywords = []string{"deg"}
+	// degreeOperator is the postfix operator of an angle measured in degrees, like 30° or x°
+	degreeOperator = "°"
 
 	// ErrUnknownExpression is thrown when GoMath does not know the expression
 	ErrUnknownExpression = errors.New("unknown expression")
@@ -36,13 +47,18 @@ type Ast struct {
 	Body statement
 }
 
-type expressionFunc func(*lexer.TokenList) (expression.Expression, error)
+type expressionFunc func(*lexer.TokenList, *expression.Registry) (expression.Expression, error)
 
 func (a *Ast) ChangeType(tpe Type) error {
 	a.Type = tpe
 	return a.setStatement(a.Body.getExpr())
 }
 
+// Expression returns the Expression parsed
+func (a *Ast) Expression() expression.Expression {
+	return a.Body.getExpr()
+}
+
 func (a *Ast) String() string {
 	m, err := json.MarshalIndent(a, "", "  ")
 	if err != nil {
@@ -63,13 +79,19 @@ func (a *Ast) setStatement(expr expression.Expression) error {
 	return nil
 }
 
-// Parse the given lexer and returns an Ast
+// Parse the given lexer and returns an Ast.
+// The constants and the functions are the ones of expression.DefaultRegistry.
 func Parse(tkl *lexer.TokenList, tpe Type) (*Ast, error) {
+	return ParseWithRegistry(tkl, tpe, expression.DefaultRegistry)
+}
+
+// ParseWithRegistry parses the given lexer with the constants and the functions of reg and returns an Ast
+func ParseWithRegistry(tkl *lexer.TokenList, tpe Type, reg *expression.Registry) (*Ast, error) {
 	tree := &Ast{Type: tpe}
 	if !tkl.Next() {
 		return nil, ErrInvalidExpression
 	}
-	exp, err := termExpression(tkl)
+	exp, err := equationExpression(tkl, reg)
 	if err != nil {
 		return nil, err
 	}
@@ -79,52 +101,178 @@ func Parse(tkl *lexer.TokenList, tpe Type) (*Ast, error) {
 	return tree, tree.setStatement(exp) // works because tree is a pointer
 }
 
-func termExpression(tkl *lexer.TokenList) (expression.Expression, error) {
-	return binExpression(termOperators, omitParenthesisExpression, tkl)
+// equationExpression parses an expression or an equation, like lhs = rhs
+func equationExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	left, err := orExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	if tkl.Empty() || tkl.Current().Value != "=" {
+		return left, nil
+	}
+	if !tkl.Next() {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("right side of the equation excepted"))
+	}
+	right, err := orExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	return expression.NewEquation(left, right), nil
+}
+
+// orExpression parses conditions separated by or, like a < b or c
+func orExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	return logicExpression("or", andExpression, expression.Or, tkl, reg)
+}
+
+// andExpression parses conditions separated by and, like a < b and c
+func andExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	return logicExpression("and", notExpression, expression.And, tkl, reg)
+}
+
+func logicExpression(
+	keyword string,
+	sub expressionFunc,
+	op func(l, r expression.Condition) expression.Condition,
+	tkl *lexer.TokenList,
+	reg *expression.Registry,
+) (expression.Expression, error) {
+	left, err := sub(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(tkl, keyword) {
+		if !tkl.Next() {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("condition excepted after %s", keyword))
+		}
+		right, err := sub(tkl, reg)
+		if err != nil {
+			return nil, err
+		}
+		l, r, err := conditions(keyword, left, right)
+		if err != nil {
+			return nil, err
+		}
+		left = op(l, r)
+	}
+	return left, nil
+}
+
+// notExpression parses a condition optionally preceded by not, like not a < b
+func notExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	if !isKeyword(tkl, "not") {
+		return comparisonExpression(tkl, reg)
+	}
+	if !tkl.Next() {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("condition excepted after not"))
+	}
+	exp, err := notExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := exp.(expression.Condition)
+	if !ok {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("not excepts a condition"))
+	}
+	return expression.Not(c), nil
+}
+
+// comparisonExpression parses comparisons, like a <= b.
+// Chained comparisons like a < b < c are read a < b and b < c.
+func comparisonExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	left, err := termExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	var res expression.Condition
+	for !tkl.Empty() && tkl.Current().Type == lexer.Operator && expression.IsComparisonOperator(tkl.Current().Value) {
+		op := tkl.Current().Value
+		if !tkl.Next() {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("right side of %s excepted", op))
+		}
+		right, err := termExpression(tkl, reg)
+		if err != nil {
+			return nil, err
+		}
+		c, err := expression.Compare(op, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = c
+		} else {
+			res = expression.And(res, c)
+		}
+		left = right
+	}
+	if res == nil {
+		return left, nil
+	}
+	return res, nil
+}
+
+// conditions returns the given Expression as conditions used by the keyword
+func conditions(keyword string, left, right expression.Expression) (expression.Condition, expression.Condition, error) {
+	l, okL := left.(expression.Condition)
+	r, okR := right.(expression.Condition)
+	if !okL || !okR {
+		return nil, nil, errors.Join(ErrInvalidExpression, fmt.Errorf("%s excepts conditions", keyword))
+	}
+	return l, r, nil
+}
+
+// isKeyword returns true if the current token is the given logic keyword
+func isKeyword(tkl *lexer.TokenList, keyword string) bool {
+	return !tkl.Empty() && tkl.Current().Type == lexer.Literal && tkl.Current().Value == keyword
+}
+
+func termExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	return binExpression(termOperators, omitParenthesisExpression, tkl, reg)
 }
 
-func omitParenthesisExpression(tkl *lexer.TokenList) (expression.Expression, error) {
+func omitParenthesisExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
 	return omitExpression(factorExpression, func(l *lexer.Lexer) bool {
 		return l.Type == lexer.Separator && l.Value == "("
-	}, tkl)
+	}, tkl, reg)
 }
 
-func factorExpression(tkl *lexer.TokenList) (expression.Expression, error) {
-	return binExpression(factorOperators, omitLiteralExpression, tkl)
+func factorExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	return binExpression(factorOperators, omitLiteralExpression, tkl, reg)
 }
 
-func omitLiteralExpression(tkl *lexer.TokenList) (expression.Expression, error) {
+func omitLiteralExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
 	return omitExpression(expExpression, func(l *lexer.Lexer) bool {
-		return l.Type == lexer.Literal
-	}, tkl)
+		return l.Type == lexer.Literal && !slices.Contains(logicKeywords, l.Value)
+	}, tkl, reg)
 }
 
-func expExpression(tkl *lexer.TokenList) (expression.Expression, error) {
-	res, err := binExpression(expOperators, literalExpression, tkl)
+func expExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	res, err := binExpression(expOperators, literalExpression, tkl, reg)
 	if err != nil {
 		return nil, err
 	}
-	if tkl.Empty() {
-		return res, nil
+	if !tkl.Empty() && tkl.Current().Type == lexer.Operator && tkl.Current().Value == "!" {
+		tkl.Next()
+		res = expression.Factorial(res)
 	}
-	if tkl.Current().Type != lexer.Operator || tkl.Current().Value != "!" {
-		return res, nil
+	if !tkl.Empty() && isDegree(tkl.Current()) {
+		tkl.Next()
+		res = expression.Degrees(res)
 	}
-	tkl.Next()
-	return expression.Factorial(res), nil
+	return res, nil
 }
 
-func binExpression(ops []string, sub expressionFunc, tkl *lexer.TokenList) (expression.Expression, error) {
-	left, err := sub(tkl)
+func binExpression(ops []string, sub expressionFunc, tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	left, err := sub(tkl, reg)
 	if err != nil {
 		return nil, err
 	}
 	for !tkl.Empty() && slices.Contains(ops, tkl.Current().Value) {
 		op := tkl.Current().Value
 		if !tkl.Next() {
-			return nil, ErrInvalidExpression
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("right side of %s excepted", op))
 		}
-		right, err := sub(tkl)
+		right, err := sub(tkl, reg)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +287,8 @@ func binExpression(ops []string, sub expressionFunc, tkl *lexer.TokenList) (expr
 			left = expression.Div(left, right)
 		case "^":
 			left = expression.Pow(left, right)
+		case "%":
+			left = expression.Mod(left, right)
 		default:
 			return nil, errors.Join(expression.ErrUnknownOperation, fmt.Errorf("unknown operator %s", op))
 		}
@@ -146,13 +296,18 @@ func binExpression(ops []string, sub expressionFunc, tkl *lexer.TokenList) (expr
 	return left, nil
 }
 
-func omitExpression(sub expressionFunc, cond func(*lexer.Lexer) bool, tkl *lexer.TokenList) (expression.Expression, error) {
-	left, err := sub(tkl)
+func omitExpression(
+	sub expressionFunc,
+	cond func(*lexer.Lexer) bool,
+	tkl *lexer.TokenList,
+	reg *expression.Registry,
+) (expression.Expression, error) {
+	left, err := sub(tkl, reg)
 	if err != nil {
 		return nil, err
 	}
 	for !tkl.Empty() && cond(tkl.Current()) {
-		right, err := sub(tkl)
+		right, err := sub(tkl, reg)
 		if err != nil {
 			return nil, err
 		}
@@ -161,30 +316,50 @@ func omitExpression(sub expressionFunc, cond func(*lexer.Lexer) bool, tkl *lexer
 	return left, nil
 }
 
-func literalExpression(tkl *lexer.TokenList) (expression.Expression, error) {
+func literalExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
 	c := tkl.Current()
+	if c == nil {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("operand excepted"))
+	}
 	tkl.Next()
 	switch c.Type {
 	case lexer.Number:
-		v, err := strconv.ParseFloat(c.Value, 64)
-		if err != nil {
-			return nil, err
-		}
-		f, err := math.FloatToFraction(v)
+		f, err := math.StringToFraction(c.Value)
 		if err != nil {
 			return nil, err
 		}
 		return expression.Const(f), nil
 	case lexer.Literal:
-		if expression.IsPredefinedFunction(c.Value) {
-			return predefinedFunction(tkl, c.Value)
+		if slices.Contains(logicKeywords, c.Value) || slices.Contains(degreeKeywords, c.Value) {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("invalid usage of %s", c.Value))
 		}
-		return expression.LiteralExpression(c.Value)
+		if slices.Contains(conditionalFunctions, c.Value) {
+			return conditionalFunction(tkl, reg, c.Value)
+		}
+		if c.Value == integralFunction {
+			return integralExpression(tkl, reg)
+		}
+		if c.Value == conversionFunction {
+			return conversionExpression(tkl, reg)
+		}
+		if reg.HasUnits() && !isCall(tkl) {
+			// a function used without arguments is a unit, like min
+			if _, ok := math.ParseUnit(c.Value); ok {
+				return reg.Literal(c.Value), nil
+			}
+		}
+		if expression.IsBinaryFunction(c.Value) {
+			return binaryFunction(tkl, reg, c.Value)
+		}
+		if reg.IsFunction(c.Value) {
+			return predefinedFunction(tkl, reg, c.Value)
+		}
+		return reg.Literal(c.Value), nil
 	case lexer.Separator:
 		if c.Value != "(" {
 			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("illegal separator %s", c.Value))
 		}
-		exp, err := termExpression(tkl)
+		exp, err := orExpression(tkl, reg)
 		if err != nil {
 			return nil, err
 		}
@@ -196,45 +371,145 @@ func literalExpression(tkl *lexer.TokenList) (expression.Expression, error) {
 		tkl.Next()
 		return exp, nil
 	case lexer.Operator:
-		exp, err := expExpression(tkl)
+		if isDegree(c) {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("operand of %s excepted before it", c.Value))
+		}
+		if c.Value != "-" && c.Value != "+" {
+			return nil, errors.Join(expression.ErrUnknownOperation, fmt.Errorf("unknown unary operator %s", c.Value))
+		}
+		if tkl.Empty() {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("operand of %s excepted", c.Value))
+		}
+		exp, err := expExpression(tkl, reg)
 		if err != nil {
 			return nil, err
 		}
-		switch c.Value {
-		case "-":
+		if c.Value == "-" {
 			exp = expression.Neg(exp)
-		case "+":
-		default:
-			return nil, errors.Join(expression.ErrUnknownOperation, fmt.Errorf("unknown unary operator %s", c.Value))
 		}
 		return exp, nil
 	}
 	return nil, errors.Join(ErrUnknownExpression, fmt.Errorf("unknown type %s: excepting a valid literal expression", c))
 }
 
-func predefinedFunction(tkl *lexer.TokenList, id string) (expression.Expression, error) {
-	exp, err := operatorExpression(tkl)
+func predefinedFunction(tkl *lexer.TokenList, reg *expression.Registry, id string) (expression.Expression, error) {
+	args, err := argumentsExpression(tkl, reg)
 	if err != nil {
 		return nil, err
 	}
-	return expression.LiteralFunction(id, exp), nil
+	return reg.Function(id, args...)
 }
 
-func operatorExpression(tkl *lexer.TokenList) (expression.Expression, error) {
-	c := tkl.Current()
-	if c.Type == lexer.Separator && c.Value == "(" {
+func binaryFunction(tkl *lexer.TokenList, reg *expression.Registry, id string) (expression.Expression, error) {
+	args, err := argumentsExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 2 {
+		return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("%s excepts 2 arguments, got %d", id, len(args)))
+	}
+	return expression.BinaryFunction(id, args[0], args[1]), nil
+}
+
+// conditionalFunction parses if(cond, a, b) and piecewise(cond1, v1, cond2, v2, ..., otherwise)
+func conditionalFunction(tkl *lexer.TokenList, reg *expression.Registry, id string) (expression.Expression, error) {
+	args, err := argumentsExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	if id == "if" && len(args) != 3 {
+		return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("if excepts 3 arguments, got %d", len(args)))
+	}
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, errors.Join(
+			ErrInvalidExpression,
+			fmt.Errorf("%s excepts pairs of condition and value followed by a value, got %d arguments", id, len(args)),
+		)
+	}
+	n := len(args) / 2
+	conds := make([]expression.Condition, n)
+	values := make([]expression.Expression, n)
+	for i := range n {
+		c, ok := args[2*i].(expression.Condition)
+		if !ok {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("argument %d of %s must be a condition", 2*i+1, id))
+		}
+		conds[i] = c
+		values[i] = args[2*i+1]
+	}
+	return expression.Piecewise(conds, values, args[len(args)-1]), nil
+}
+
+// integralExpression parses integrate(f, x, a, b), the integral of f with respect to x between a and b
+func integralExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	args, err := argumentsExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 4 {
+		return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("integrate excepts 4 arguments, got %d", len(args)))
+	}
+	vars := expression.Variables(args[1])
+	if len(vars) != 1 || expression.String(args[1]) != vars[0] {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("argument 2 of integrate must be a variable"))
+	}
+	return expression.Integral(args[0], vars[0], args[2], args[3]), nil
+}
+
+// conversionExpression parses convert(q, u), the quantity q converted in the unit u
+func conversionExpression(tkl *lexer.TokenList, reg *expression.Registry) (expression.Expression, error) {
+	args, err := argumentsExpression(tkl, reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 2 {
+		return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("%s excepts 2 arguments, got %d", conversionFunction, len(args)))
+	}
+	return expression.Convert(args[0], args[1]), nil
+}
+
+// isDegree returns true if the token marks an angle measured in degrees, like ° or deg
+func isDegree(t *lexer.Lexer) bool {
+	return (t.Type == lexer.Operator && t.Value == degreeOperator) ||
+		(t.Type == lexer.Literal && slices.Contains(degreeKeywords, t.Value))
+}
+
+// IsKeyword returns true if id is reserved by the parser, like and or if
+func IsKeyword(id string) bool {
+	return slices.Contains(logicKeywords, id) || slices.Contains(conditionalFunctions, id) || id == integralFunction ||
+		id == conversionFunction || slices.Contains(degreeKeywords, id)
+}
+
+// isCall returns true if the next token starts the arguments of a function, like (a, b)
+func isCall(tkl *lexer.TokenList) bool {
+	return !tkl.Empty() && tkl.Current().Type == lexer.Separator && tkl.Current().Value == "("
+}
+
+// argumentsExpression parses the arguments of a function call, like (a, b)
+func argumentsExpression(tkl *lexer.TokenList, reg *expression.Registry) ([]expression.Expression, error) {
+	if !isCall(tkl) {
+		return nil, errors.Join(ErrInvalidExpression, errors.New("( excepted after a function"))
+	}
+	var args []expression.Expression
+	for {
 		if !tkl.Next() {
 			return nil, ErrInvalidExpression
 		}
-		exp, err := termExpression(tkl)
+		exp, err := orExpression(tkl, reg)
 		if err != nil {
 			return nil, err
 		}
-		if tkl.Current().Value != ")" {
-			return exp, errors.Join(ErrInvalidExpression, fmt.Errorf(") excepted, not %s", tkl.Current().Value))
+		args = append(args, exp)
+		if tkl.Empty() {
+			return nil, errors.Join(ErrInvalidExpression, fmt.Errorf("')' excepted"))
+		}
+		switch tkl.Current().Value {
+		case ",":
+			continue
+		case ")":
+			tkl.Next()
+			return args, nil
 		}
-		tkl.Next()
-		return exp, nil
+		return nil, errors.Join(ErrInvalidExpression, fmt.Errorf(") excepted, not %s", tkl.Current().Value))
 	}
-	return nil, ErrInvalidExpression
 }
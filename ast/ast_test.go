@@ -26,5 +26,15 @@ func TestAstErrors(t *testing.T) {
 	genericTestAstError("1+1)", ErrInvalidExpression)
 	genericTestAstError("(1+1", ErrInvalidExpression)
 	genericTestAstError("1+1+", ErrInvalidExpression)
+	genericTestAstError("deg", ErrInvalidExpression)
+	genericTestAstError("sin(°)", ErrInvalidExpression)
+	genericTestAstError("convert(1)", ErrInvalidExpression)
+	// missing operands
+	genericTestAstError("°", ErrInvalidExpression)
+	genericTestAstError("-", ErrInvalidExpression)
+	genericTestAstError("(", ErrInvalidExpression)
+	genericTestAstError("1 <= ", ErrInvalidExpression)
+	genericTestAstError("1 % ", ErrInvalidExpression)
+	genericTestAstError("2*", ErrInvalidExpression)
 	//genericTestAstError("1×1+1", ErrInvalidExpression) // will be valid when omission between number and literal is added
 }
@@ -3,14 +3,80 @@ package ast
 import (
 	"github.com/nyttikord/gomath/expression"
 	"github.com/nyttikord/gomath/math"
+	"strconv"
 )
 
 type Options struct {
 	Decimal   bool
 	Precision int
+	// Scope contains the user-defined variables used during the evaluation (can be nil)
+	Scope *expression.Scope
+	// Registry contains the constants and the functions used during the parsing (expression.DefaultRegistry if nil)
+	Registry *expression.Registry
+	// Angle is the unit of the angles used by the trigonometric functions (expression.Radian by default)
+	Angle expression.AngleUnit
+	// Units enables the units of measurement during the parsing, like 3 m + 20 cm (see expression.Registry.SetUnits)
+	Units bool
+	// Rounding is the rule used to round the decimal results (half away from zero without trailing zeros by default)
+	Rounding math.Rounding
+	// Money enables the money mode: the results are amounts rounded to a fixed number of decimals, like 12.50 EUR
+	Money *MoneyOptions
 }
+
+// MoneyOptions configures the money mode
+type MoneyOptions struct {
+	// Decimals is the number of decimals of the results
+	Decimals int
+	// Rounding is the rule used to round the results (math.HalfUp by default)
+	Rounding math.RoundingMode
+	// Rates contains the conversion rates of the currencies (can be nil if the expressions use only one currency)
+	Rates *math.RateTable
+}
+
+// ParseRegistry returns the Registry used to parse an expression: Registry with the units and the currencies enabled
+// by the Options
+func (o *Options) ParseRegistry() *expression.Registry {
+	reg := o.Registry
+	if reg == nil {
+		reg = expression.DefaultRegistry
+	}
+	units := o.Units && !reg.HasUnits()
+	money := o.Money != nil && !reg.HasMoney()
+	if !units && !money {
+		return reg
+	}
+	reg = reg.Clone()
+	if units {
+		reg.SetUnits(true)
+	}
+	if money {
+		reg.SetMoney(true)
+	}
+	return reg
+}
+
+// EvalScope returns the Scope used to evaluate an expression: Scope with the angle unit, the conversion rates and the
+// rounding of the money mode of the Options
+func (o *Options) EvalScope() *expression.Scope {
+	if o.Angle == expression.Radian && o.Money == nil {
+		return o.Scope
+	}
+	s := expression.NewScope(o.Scope)
+	if o.Angle != expression.Radian {
+		s.SetAngleUnit(o.Angle)
+	}
+	if o.Money != nil {
+		s.SetRates(o.Money.Rates)
+		s.SetMoneyRounding(o.Money.Decimals, o.Money.Rounding)
+	}
+	return s
+}
+
 type StatementResult struct {
-	fraction *math.Fraction
+	complex  *math.Complex
+	boolean  *bool
+	quantity *math.Quantity
+	money    *math.Money
 	result   string
 }
 
@@ -20,9 +86,56 @@ func (c *StatementResult) String() string {
 }
 
 // Fraction gives the computed fraction during the evaluation.
-// Is nil if no fraction was computed
+// Is nil if no fraction was computed or if the computed number is irrational
 func (c *StatementResult) Fraction() *math.Fraction {
-	return c.fraction
+	if c.complex == nil {
+		return nil
+	}
+	f, ok := c.complex.Fraction()
+	if !ok {
+		return nil
+	}
+	return f
+}
+
+// Real gives the computed number during the evaluation.
+// Is nil if no number was computed or if the computed number is not real
+func (c *StatementResult) Real() *math.Real {
+	if c.complex == nil {
+		return nil
+	}
+	r, ok := c.complex.Real()
+	if !ok {
+		return nil
+	}
+	return r
+}
+
+// Complex gives the computed number during the evaluation.
+// Is nil if no number was computed
+func (c *StatementResult) Complex() *math.Complex {
+	return c.complex
+}
+
+// Quantity gives the physical quantity computed during the evaluation, like 16/5 m.
+// Is nil if no quantity having a unit was computed
+func (c *StatementResult) Quantity() *math.Quantity {
+	return c.quantity
+}
+
+// Money gives the amount computed during the evaluation in the money mode, like 25/2 EUR.
+// Is nil if the money mode was disabled
+func (c *StatementResult) Money() *math.Money {
+	return c.money
+}
+
+// Boolean gives the truth value computed during the evaluation of a condition.
+// The second value is false if the statement was not a condition
+func (c *StatementResult) Boolean() (bool, bool) {
+	if c.boolean == nil {
+		return false, false
+	}
+	return *c.boolean, true
 }
 
 type statement interface {
@@ -37,20 +150,69 @@ type calculationStatement struct {
 }
 
 func (p *calculationStatement) Eval(opt *Options) (*StatementResult, error) {
-	f, err := p.Expression.Eval()
+	if c, ok := p.Expression.(expression.Condition); ok {
+		b, err := c.Test(opt.EvalScope())
+		if err != nil {
+			return nil, err
+		}
+		r := &StatementResult{boolean: &b}
+		r.result = strconv.FormatBool(b)
+		return r, nil
+	}
+	if opt.Money != nil {
+		return evalMoney(p.Expression, opt)
+	}
+	if expression.HasUnit(p.Expression) {
+		return evalQuantity(p.Expression, opt)
+	}
+	f, err := p.Expression.Eval(opt.EvalScope())
 	if err != nil {
 		return nil, err
 	}
 	r := &StatementResult{}
-	r.fraction = f
+	r.complex = f
 	if opt.Decimal {
-		r.result = f.Approx(opt.Precision)
+		r.result = f.ApproxWith(opt.Precision, opt.Rounding)
 		return r, nil
 	}
 	r.result = f.String()
 	return r, nil
 }
 
+// evalQuantity evaluates an expression using units, the result being a number if the units cancel each other out
+func evalQuantity(e expression.Expression, opt *Options) (*StatementResult, error) {
+	q, err := expression.EvalQuantity(e, opt.EvalScope())
+	if err != nil {
+		return nil, err
+	}
+	r := &StatementResult{}
+	if q.IsDimensionless() {
+		r.complex = math.FractionToComplex(q.Value)
+	} else {
+		r.quantity = q
+	}
+	if opt.Decimal {
+		r.result = q.ApproxWith(opt.Precision, opt.Rounding)
+		return r, nil
+	}
+	r.result = q.String()
+	return r, nil
+}
+
+// evalMoney evaluates an expression in the money mode, the result being rounded to the decimals of the MoneyOptions
+func evalMoney(e expression.Expression, opt *Options) (*StatementResult, error) {
+	m, err := expression.EvalMoney(e, opt.EvalScope(), opt.Money.Rates)
+	if err != nil {
+		return nil, err
+	}
+	r := &StatementResult{money: m}
+	if m.IsNumber() {
+		r.complex = math.FractionToComplex(m.Amount)
+	}
+	r.result = m.FixedString(opt.Money.Decimals, opt.Money.Rounding)
+	return r, nil
+}
+
 func (p *calculationStatement) getExpr() expression.Expression {
 	return p.Expression
 }
@@ -66,7 +228,7 @@ func (l *latexStatement) Eval(_ *Options) (*StatementResult, error) {
 	}
 	r := &StatementResult{}
 	r.result = s
-	r.fraction = nil
+	r.complex = nil
 	return r, nil
 }
 
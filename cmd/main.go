@@ -1,19 +1,37 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"github.com/nyttikord/gomath"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
 	"os"
 	"strings"
 )
 
 var (
 	precision = uint(6)
+	angle     = "rad"
+	units     = false
+	money     = false
+	decimals  = uint(2)
+	rounding  = "half-up"
+	zeros     = false
+	rates     = ""
 )
 
 func init() {
 	flag.UintVar(&precision, "p", precision, "precision level")
+	flag.StringVar(&angle, "angle", angle, "unit of the angles: deg, rad or grad")
+	flag.BoolVar(&units, "units", units, "enable the units of measurement, like 3 m + 20 cm")
+	flag.BoolVar(&money, "money", money, "enable the money mode, like 12.5 EUR + 3 USD")
+	flag.UintVar(&decimals, "decimals", decimals, "number of decimals of the amounts in the money mode")
+	flag.StringVar(&rounding, "rounding", rounding, "rounding rule: half-up, half-even, half-down, toward-zero, floor or ceiling")
+	flag.BoolVar(&zeros, "zeros", zeros, "keep the trailing zeros of the decimal approximation")
+	flag.StringVar(&rates, "rates", rates, "conversion rates of the money mode, like EUR,USD=0.92,GBP=1.17")
 }
 
 func main() {
@@ -23,6 +41,24 @@ func main() {
 		fmt.Printf("Usage: %s <subcommand>\nUse '%s help' for more information.\n", os.Args[0], os.Args[0])
 		os.Exit(1)
 	}
+	angleUnit, err := expression.ParseAngleUnit(angle)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	mode, err := math.ParseRoundingMode(rounding)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	opt := &ast.Options{Angle: angleUnit, Units: units, Rounding: math.Rounding{Mode: mode, KeepZeros: zeros}}
+	if money {
+		opt.Money, err = moneyOptions(mode)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 	subcommand := args[0]
 	switch subcommand {
 	case "help":
@@ -34,9 +70,20 @@ func main() {
 			"Usage: %s [flags] <subcommand>\n\nSubcommands:\n"+
 				"- help               -> print this help text\n"+
 				"- eval <expression>  -> evaluate an expression.\n"+
-				"- latex <expression> -> convert an expression to LaTeX code.\n\n"+
+				"- latex <expression> -> convert an expression to LaTeX code.\n"+
+				"- solve <equation>   -> solve an equation in one unknown, like x^2 = 2.\n"+
+				"- diff <var> <expr>  -> derive an expression with respect to a variable, like diff x x^2.\n"+
+				"- simplify <expr>    -> simplify an expression, like simplify 2x + 3x - x.\n"+
+				"- repl               -> start an interactive session keeping variables and functions.\n\n"+
 				"Flags:\n"+
-				"- p uint -> define the precision of the decimal approximation\n",
+				"- p uint             -> define the precision of the decimal approximation\n"+
+				"- angle deg|rad|grad -> define the unit of the angles used by the trigonometric functions\n"+
+				"- units              -> enable the units of measurement, like 3 m + 20 cm\n"+
+				"- money              -> enable the money mode, like 12.5 EUR + 3 USD\n"+
+				"- decimals uint      -> define the number of decimals of the amounts in the money mode\n"+
+				"- rounding rule      -> define the rounding rule: half-up, half-even, half-down, toward-zero, floor or ceiling\n"+
+				"- zeros              -> keep the trailing zeros of the decimal approximation\n"+
+				"- rates table        -> define the conversion rates of the money mode, like EUR,USD=0.92,GBP=1.17\n",
 			os.Args[0],
 		)
 	case "eval":
@@ -45,11 +92,19 @@ func main() {
 			os.Exit(1)
 		}
 		expression := strings.Join(args[1:], " ")
-		res, err := gomath.Parse(expression)
+		res, err := gomath.Parse(expression, opt)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(2)
 		}
+		if _, ok := res.Boolean(); ok {
+			fmt.Printf("Result:  %s\n", res)
+			return
+		}
+		if _, ok := res.Money(); ok {
+			fmt.Printf("Result:  %s\n", res)
+			return
+		}
 		fmt.Printf("Exact:   %s\n", res)
 		fmt.Printf("Decimal: %s", res.Approx(int(precision)))
 		if res.IsExact(int(precision)) {
@@ -59,18 +114,132 @@ func main() {
 		}
 		fmt.Println()
 	case "latex":
-		if len(os.Args) < 3 {
+		if len(args[1:]) == 0 {
 			fmt.Printf("Usage: '%s latex <expression>'.\n", os.Args[0])
 			os.Exit(1)
 		}
-		expression := strings.Join(os.Args[2:], " ")
-		res, err := gomath.ParseAndConvertToLaTeX(expression, nil)
+		expression := strings.Join(args[1:], " ")
+		res, err := gomath.ParseAndConvertToLaTeX(expression, opt)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(2)
 		}
 		fmt.Println(res)
+	case "solve":
+		if len(args[1:]) == 0 {
+			fmt.Printf("Usage: '%s solve <equation>'.\n", os.Args[0])
+			os.Exit(1)
+		}
+		equation := strings.Join(args[1:], " ")
+		sol, err := gomath.Solve(equation, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		fmt.Printf("Solutions: %s\n", sol)
+		if set, ok := sol.(*math.FiniteSet); ok {
+			fmt.Printf("Decimal:   %s\n", set.Approx(int(precision)))
+		}
+	case "diff":
+		if len(args[1:]) < 2 {
+			fmt.Printf("Usage: '%s diff <variable> <expression>'.\n", os.Args[0])
+			os.Exit(1)
+		}
+		exp := strings.Join(args[2:], " ")
+		d, err := gomath.Derive(exp, args[1], opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		latex, _, err := d.RenderLatex()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		fmt.Printf("Plain: %s\n", expression.String(d))
+		fmt.Printf("LaTeX: %s\n", latex)
+	case "simplify":
+		if len(args[1:]) == 0 {
+			fmt.Printf("Usage: '%s simplify <expression>'.\n", os.Args[0])
+			os.Exit(1)
+		}
+		exp := strings.Join(args[1:], " ")
+		s, err := gomath.Simplify(exp, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		latex, _, err := s.RenderLatex()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		fmt.Printf("Plain: %s\n", expression.String(s))
+		fmt.Printf("LaTeX: %s\n", latex)
+	case "repl":
+		if len(args) != 1 {
+			fmt.Printf("'repl' does not take any arguments.\n")
+			os.Exit(1)
+		}
+		repl(angleUnit, opt.Rounding)
 	default:
 		fmt.Printf("Unknown subcommand: %s\nUse '%s help' for more information.\n", subcommand, os.Args[0])
 	}
 }
+
+// moneyOptions returns the options of the money mode given by the flags
+func moneyOptions(mode math.RoundingMode) (*ast.MoneyOptions, error) {
+	opt := &ast.MoneyOptions{Decimals: int(decimals), Rounding: mode}
+	if rates == "" {
+		return opt, nil
+	}
+	// the first element is the base currency, the others are CODE=RATE
+	parts := strings.Split(rates, ",")
+	opt.Rates = math.NewRateTable(parts[0])
+	for _, p := range parts[1:] {
+		code, value, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %s (excepted CODE=RATE)", p)
+		}
+		rate, err := math.StringToFraction(value)
+		if err != nil {
+			return nil, err
+		}
+		if err = opt.Rates.Set(code, rate); err != nil {
+			return nil, err
+		}
+	}
+	return opt, nil
+}
+
+// repl executes each line of the standard input in a gomath.Session
+func repl(angleUnit expression.AngleUnit, rounding math.Rounding) {
+	session := gomath.NewSession()
+	session.Precision = int(precision)
+	session.Angle = angleUnit
+	session.Rounding = rounding
+	fmt.Println("Type :vars, :funcs, :clear or :latex to use commands, :quit or Ctrl+D to exit.")
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == ":quit" {
+			return
+		}
+		out, err := session.Exec(line)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if out != "" {
+			fmt.Println(out)
+		}
+	}
+}
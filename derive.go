@@ -0,0 +1,30 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+)
+
+var (
+	// ErrInvalidVariable is thrown when the variable given is not a valid variable name
+	ErrInvalidVariable = errors.New("invalid variable")
+)
+
+// Derive returns the derivative of the expression with respect to the variable x, like 2 \times x for x^2.
+// The optional Options give the expression.Registry used to parse the expression.
+// Use RenderLatex to get the LaTeX code of the derivative.
+func Derive(exp string, x string, opts ...*ast.Options) (expression.Expression, error) {
+	if err := checkParameter(x, nil); err != nil {
+		return nil, errors.Join(ErrInvalidVariable, err)
+	}
+	var opt *ast.Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	tree, err := parseAst(exp, ast.TypeCalculation, opt)
+	if err != nil {
+		return nil, err
+	}
+	return expression.Derive(tree.Expression(), x)
+}
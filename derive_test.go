@@ -0,0 +1,104 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"strings"
+	"testing"
+)
+
+func TestDerive(t *testing.T) {
+	genericTest := func(exp, excepted string) {
+		d, err := Derive(exp, "x")
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		got, _, err := d.RenderLatex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+	}
+	genericTest("x^2", `2 \times x`)
+	genericTest("x^3 + 2x + 1", `3 \times x^2 + 2`)
+	genericTest("-x^2", `-2 \times x`)
+	genericTest("a*x^2", `2 \times a \times x`)
+	genericTest("x*sin(x)", `x \times \cos\left(x\right) + \sin\left(x\right)`)
+	genericTest("sin(x)^2", `2 \times \cos\left(x\right) \times \sin\left(x\right)`)
+	genericTest("sin(x)/x", `\frac{x \times \cos\left(x\right) - \sin\left(x\right)}{x^2}`)
+	genericTest("exp(2x)", `2 \times \exp\left(2 \times x\right)`)
+	genericTest("e^x", `e^x`)
+	genericTest("2^x", `\ln\left(2\right) \times 2^x`)
+	genericTest("ln(x^2 + 1)", `\frac{2 \times x}{x^2 + 1}`)
+	genericTest("sqrt(x)", `\frac{1}{2 \times \sqrt{x}}`)
+	genericTest("log(2, x)", `\frac{1}{x \times \ln\left(2\right)}`)
+	genericTest("if(x > 0, x^2, -x)", `\begin{cases} 2 \times x & \text{if } x > 0 \\ -1 & \text{otherwise} \end{cases}`)
+	genericTest("y^2", "0")
+	genericTest("x°", `1^\circ`)
+}
+
+func TestDerive_String(t *testing.T) {
+	genericTest := func(exp, excepted string) {
+		d, err := Derive(exp, "x")
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if got := expression.String(d); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+	}
+	genericTest("3x^3 - 2x + 1", "9x^2 - 2")
+	genericTest("x*x*x", "3x^2")
+	genericTest("x^(1/2)", "1/(2sqrt(x))")
+	genericTest("ln(-x)", "1/x")
+	genericTest("exp(x)^2", "2exp(x)^2")
+	genericTest("1/x", "-1/x^2")
+	genericTest("log(2, x)", "1/(x*ln(2))")
+}
+
+func TestDerive_Eval(t *testing.T) {
+	// the derivatives are checked at x = 1/2
+	genericTest := func(exp, excepted string) {
+		d, err := Derive(exp, "x")
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		scope := expression.NewScope(nil)
+		scope.SetFraction("x", math.NewFraction(1, 2))
+		v, err := d.Eval(scope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.Approx(6); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+	}
+	genericTest("tan(x)", "1.298446")
+	genericTest("cos(3x)", "-2.992485")
+	genericTest("sin(60x°)", "0.9069")
+	genericTest("x°", "0.017453")
+	genericTest("x^x", "0.216978")
+	genericTest("root(3, x)", "0.529134")
+	genericTest("atan2(x, 1)", "0.8")
+	genericTest("log10(x)", "0.868589")
+	genericTest("asin(x)", "1.154701")
+	genericTest("atanh(x)", "1.333333")
+	genericTest("sinh(x)", "1.127626")
+}
+
+func TestDerive_Errors(t *testing.T) {
+	if _, err := Derive("x!", "x"); !errors.Is(err, expression.ErrNotDerivable) {
+		t.Errorf("excepted not derivable error, got %v", err)
+	} else if !strings.Contains(err.Error(), "x! cannot be derived") {
+		t.Errorf("the error must show the expression, got %v", err)
+	}
+	if _, err := Derive("max(x, 1)", "x"); !errors.Is(err, expression.ErrNotDerivable) {
+		t.Errorf("excepted not derivable error, got %v", err)
+	}
+	if _, err := Derive("x^2", "pi"); !errors.Is(err, ErrInvalidVariable) {
+		t.Errorf("excepted invalid variable error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// AngleUnit is the unit of the angles taken and returned by the trigonometric functions
+type AngleUnit uint
+
+const (
+	Radian AngleUnit = iota
+	Degree
+	Gradian
+)
+
+var (
+	// ErrUnknownAngleUnit is thrown when GoMath does not know the given angle unit
+	ErrUnknownAngleUnit = errors.New("unknown angle unit")
+
+	angleUnitNames = map[AngleUnit]string{Radian: "rad", Degree: "deg", Gradian: "grad"}
+)
+
+// ParseAngleUnit returns the AngleUnit named rad, deg or grad
+func ParseAngleUnit(s string) (AngleUnit, error) {
+	for u, n := range angleUnitNames {
+		if n == s {
+			return u, nil
+		}
+	}
+	return Radian, errors.Join(ErrUnknownAngleUnit, fmt.Errorf("%s (excepted rad, deg or grad)", s))
+}
+
+func (u AngleUnit) String() string {
+	return angleUnitNames[u]
+}
+
+// halfTurn returns the measure of a half turn in the unit
+func (u AngleUnit) halfTurn() *math.Complex {
+	switch u {
+	case Degree:
+		return math.IntToComplex(180)
+	case Gradian:
+		return math.IntToComplex(200)
+	}
+	return math.RealToComplex(math.Pi)
+}
+
+// Period returns the period of the trigonometric functions in the unit, like 2pi for Radian
+func (u AngleUnit) Period() *math.Complex {
+	return u.halfTurn().Mul(math.IntToComplex(2))
+}
+
+// convert the angle z measured in u to the unit to
+func (u AngleUnit) convert(z *math.Complex, to AngleUnit) (*math.Complex, error) {
+	if u == to {
+		return z, nil
+	}
+	return z.Mul(to.halfTurn()).Div(u.halfTurn())
+}
+
+// degree is the angle Left measured in degrees, like 30°.
+// It is evaluated in the AngleUnit of the Scope, so sin(30°) is 1/2 whatever the unit.
+type degree struct {
+	Left Expression
+}
+
+// Degrees returns the angle l measured in degrees
+func Degrees(l Expression) Expression {
+	return &degree{l}
+}
+
+func (d *degree) Eval(s *Scope) (*math.Complex, error) {
+	v, err := d.Left.Eval(s)
+	if err != nil {
+		return nil, err
+	}
+	return Degree.convert(v, s.AngleUnit())
+}
+
+func (d *degree) RenderLatex() (string, priority, error) {
+	l, p, err := d.Left.RenderLatex()
+	if err != nil {
+		return "", expPriority, err
+	}
+	if p < literalPriority {
+		l = `\left(` + l + `\right)`
+	}
+	return l + `^\circ`, expPriority, nil
+}
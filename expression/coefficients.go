@@ -0,0 +1,143 @@
+package expression
+
+import (
+	"github.com/nyttikord/gomath/math"
+)
+
+const (
+	// maxPolynomialDegree is the biggest exponent expanded by Coefficients
+	maxPolynomialDegree = 64
+)
+
+// Coefficients returns the coefficients of the Expression seen as a polynomial in x, the i-th coefficient being the
+// one of x^i.
+// The boolean is false if the Expression is not a polynomial in x, like sqrt(x) or 1/x.
+// The other variables are evaluated in the given Scope.
+func Coefficients(e Expression, x string, s *Scope) ([]*math.Complex, bool, error) {
+	p, ok, err := coefficients(e, x, s)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	// removes the null leading coefficients, like in x^2 - x^2 + x
+	for len(p) > 1 && p[len(p)-1].IsNull() {
+		p = p[:len(p)-1]
+	}
+	return p, true, nil
+}
+
+func coefficients(e Expression, x string, s *Scope) ([]*math.Complex, bool, error) {
+	if !DependsOn(e, x) {
+		v, err := e.Eval(s)
+		if err != nil {
+			return nil, false, err
+		}
+		return []*math.Complex{v}, true, nil
+	}
+	switch v := e.(type) {
+	case *literalExpression:
+		return []*math.Complex{math.NullComplex, math.OneComplex}, true, nil
+	case *negation:
+		p, ok, err := coefficients(v.Left, x, s)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		return polyScale(p, math.IntToComplex(-1)), true, nil
+	case *addition:
+		l, r, ok, err := leftRightCoefficients(v.Left, v.Right, x, s)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		return polyAdd(l, r), true, nil
+	case *multiplication:
+		l, r, ok, err := leftRightCoefficients(v.Left, v.Right, x, s)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		return polyMul(l, r), true, nil
+	case *division:
+		if DependsOn(v.Right, x) {
+			return nil, false, nil
+		}
+		l, ok, err := coefficients(v.Left, x, s)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		d, err := v.Right.Eval(s)
+		if err != nil {
+			return nil, false, err
+		}
+		inv, err := d.Inv()
+		if err != nil {
+			return nil, false, err
+		}
+		return polyScale(l, inv), true, nil
+	case *pow:
+		if DependsOn(v.Right, x) {
+			return nil, false, nil
+		}
+		n, err := v.Right.Eval(s)
+		if err != nil {
+			return nil, false, err
+		}
+		f, ok := n.Fraction()
+		if !ok || !f.IsInt() || f.Sign() < 0 || f.GreaterThan(math.IntToFraction(maxPolynomialDegree)) {
+			return nil, false, nil
+		}
+		l, ok, err := coefficients(v.Left, x, s)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		i, _ := f.Int()
+		res := []*math.Complex{math.OneComplex}
+		for k := int64(0); k < i.Int64(); k++ {
+			res = polyMul(res, l)
+		}
+		return res, true, nil
+	}
+	return nil, false, nil
+}
+
+func leftRightCoefficients(left, right Expression, x string, s *Scope) ([]*math.Complex, []*math.Complex, bool, error) {
+	l, ok, err := coefficients(left, x, s)
+	if err != nil || !ok {
+		return nil, nil, ok, err
+	}
+	r, ok, err := coefficients(right, x, s)
+	if err != nil || !ok {
+		return nil, nil, ok, err
+	}
+	return l, r, true, nil
+}
+
+func polyAdd(a, b []*math.Complex) []*math.Complex {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	res := make([]*math.Complex, len(a))
+	copy(res, a)
+	for i, c := range b {
+		res[i] = res[i].Add(c)
+	}
+	return res
+}
+
+func polyMul(a, b []*math.Complex) []*math.Complex {
+	res := make([]*math.Complex, len(a)+len(b)-1)
+	for i := range res {
+		res[i] = math.NullComplex
+	}
+	for i, c := range a {
+		for j, d := range b {
+			res[i+j] = res[i+j].Add(c.Mul(d))
+		}
+	}
+	return res
+}
+
+func polyScale(a []*math.Complex, k *math.Complex) []*math.Complex {
+	res := make([]*math.Complex, len(a))
+	for i, c := range a {
+		res[i] = c.Mul(k)
+	}
+	return res
+}
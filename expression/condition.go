@@ -0,0 +1,219 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// Condition is an Expression having a truth value, like x > 3.
+// A Condition used in a calculation is evaluated to 1 if it is true and to 0 otherwise, so (x > 3)*5 is 5 if x > 3.
+type Condition interface {
+	Expression
+	// Test returns the truth value of the Condition in the given Scope (can be nil)
+	Test(*Scope) (bool, error)
+}
+
+var (
+	// comparisonOperators are the comparison operators with their LaTeX representation
+	comparisonOperators = map[string]string{
+		"<":  "<",
+		">":  ">",
+		"<=": `\leq`,
+		">=": `\geq`,
+		"==": "=",
+		"!=": `\neq`,
+	}
+)
+
+type comparison struct {
+	Left, Right Expression
+	op          string
+}
+
+type logic struct {
+	Left, Right Condition
+	isAnd       bool
+}
+
+type not struct {
+	Left Condition
+}
+
+// Compare returns the Condition l op r, op being <, >, <=, >=, == or !=
+func Compare(op string, l, r Expression) (Condition, error) {
+	if _, ok := comparisonOperators[op]; !ok {
+		return nil, errors.Join(ErrUnknownOperation, fmt.Errorf("unknown comparison %s", op))
+	}
+	return &comparison{l, r, op}, nil
+}
+
+// IsComparisonOperator returns true if op is a comparison operator, like <=
+func IsComparisonOperator(op string) bool {
+	_, ok := comparisonOperators[op]
+	return ok
+}
+
+func And(l, r Condition) Condition {
+	return &logic{l, r, true}
+}
+
+func Or(l, r Condition) Condition {
+	return &logic{l, r, false}
+}
+
+func Not(c Condition) Condition {
+	return &not{c}
+}
+
+// evalCondition evaluates the Condition to 1 if it is true and to 0 otherwise
+func evalCondition(c Condition, s *Scope) (*math.Complex, error) {
+	b, err := c.Test(s)
+	if err != nil {
+		return nil, err
+	}
+	if b {
+		return math.OneComplex, nil
+	}
+	return math.NullComplex, nil
+}
+
+func (c *comparison) Eval(s *Scope) (*math.Complex, error) {
+	return evalCondition(c, s)
+}
+
+func (c *comparison) Test(s *Scope) (bool, error) {
+	if hasMoney(c.Left, s) || hasMoney(c.Right, s) {
+		l, r, err := evalMoneyLeftRight(c.Left, c.Right, s, s.Rates())
+		if err != nil {
+			return false, err
+		}
+		if !l.IsNumber() && !r.IsNumber() {
+			r, err = s.Rates().Convert(r, l.Currency)
+			if err != nil {
+				return false, err
+			}
+		}
+		sign, err := l.Cmp(r)
+		if err != nil {
+			return false, err
+		}
+		return c.holds(sign)
+	}
+	if HasUnit(c.Left) || HasUnit(c.Right) {
+		l, r, err := evalQuantityLeftRight(c.Left, c.Right, s)
+		if err != nil {
+			return false, err
+		}
+		sign, err := l.Cmp(r)
+		if err != nil {
+			return false, err
+		}
+		return c.holds(sign)
+	}
+	lf, lr, err := getLeftRight(c.Left, c.Right, s)
+	if err != nil {
+		return false, err
+	}
+	// every operator compares the sign of the difference, so sin(1)^2 + cos(1)^2 == 1 like sin(1)^2 + cos(1)^2 <= 1
+	d := lf.Sub(lr)
+	isEquality := c.op == "==" || c.op == "!="
+	if isEquality && d.Im().Sign() != 0 {
+		return c.op == "!=", nil
+	}
+	if !isEquality && (!lf.IsReal() || !lr.IsReal()) {
+		return false, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s %s %s: complex numbers cannot be ordered", lf, c.op, lr))
+	}
+	return c.holds(d.Re().Sign())
+}
+
+// holds returns true if the comparison is true when the sign of the difference of its sides is sign
+func (c *comparison) holds(sign int) (bool, error) {
+	switch c.op {
+	case "==":
+		return sign == 0, nil
+	case "!=":
+		return sign != 0, nil
+	case "<":
+		return sign < 0, nil
+	case ">":
+		return sign > 0, nil
+	case "<=":
+		return sign <= 0, nil
+	case ">=":
+		return sign >= 0, nil
+	}
+	return false, errors.Join(ErrUnknownOperation, fmt.Errorf("unknown comparison %s", c.op))
+}
+
+func (c *comparison) RenderLatex() (string, priority, error) {
+	cf := make(chan string)
+	cr := make(chan string)
+	cpl := make(chan priority)
+	cpr := make(chan priority)
+	getLatexLeftRight(cf, cr, cpl, cpr, c.Left, c.Right)
+	lf := <-cf
+	lr := <-cr
+	pf := <-cpl
+	pr := <-cpr
+	lf = handleLatexParenthesis(lf, pf, termPriority)
+	lr = handleLatexParenthesis(lr, pr, termPriority)
+	return fmt.Sprintf("%s %s %s", lf, comparisonOperators[c.op], lr), comparisonPriority, nil
+}
+
+func (l *logic) Eval(s *Scope) (*math.Complex, error) {
+	return evalCondition(l, s)
+}
+
+// Test evaluates the right Condition only if the left one does not give the result
+func (l *logic) Test(s *Scope) (bool, error) {
+	a, err := l.Left.Test(s)
+	if err != nil {
+		return false, err
+	}
+	if a != l.isAnd {
+		// false and ..., true or ...
+		return a, nil
+	}
+	return l.Right.Test(s)
+}
+
+func (l *logic) RenderLatex() (string, priority, error) {
+	cf := make(chan string)
+	cr := make(chan string)
+	cpl := make(chan priority)
+	cpr := make(chan priority)
+	getLatexLeftRight(cf, cr, cpl, cpr, l.Left, l.Right)
+	lf := <-cf
+	lr := <-cr
+	pf := <-cpl
+	pr := <-cpr
+	p := orPriority
+	op := `\lor`
+	if l.isAnd {
+		p = andPriority
+		op = `\land`
+	}
+	lf = handleLatexParenthesis(lf, pf, p)
+	lr = handleLatexParenthesis(lr, pr, p)
+	return fmt.Sprintf("%s %s %s", lf, op, lr), p, nil
+}
+
+func (n *not) Eval(s *Scope) (*math.Complex, error) {
+	return evalCondition(n, s)
+}
+
+func (n *not) Test(s *Scope) (bool, error) {
+	b, err := n.Left.Test(s)
+	return !b, err
+}
+
+func (n *not) RenderLatex() (string, priority, error) {
+	s, p, err := n.Left.RenderLatex()
+	if err != nil {
+		return "", notPriority, err
+	}
+	// \lnot x > 3 must be read \lnot (x > 3)
+	s = handleLatexParenthesis(s, p, termPriority)
+	return `\lnot ` + s, notPriority, nil
+}
@@ -0,0 +1,335 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+var (
+	// ErrNotDerivable is thrown when the derivative of an Expression cannot be computed, like the one of x!
+	ErrNotDerivable = errors.New("expression is not derivable")
+)
+
+// Derive returns the derivative of the Expression with respect to the variable x.
+// It is simplified by Simplify: the numbers are folded and the like terms and factors are merged, like x*x*x gives 3x^2.
+func Derive(e Expression, x string) (Expression, error) {
+	d, err := derive(e, x)
+	if err != nil {
+		return nil, err
+	}
+	return Simplify(d), nil
+}
+
+// derive returns the derivative of the Expression with respect to the variable x, without simplifying it.
+// The trivial terms are removed, like 0 + u or 1*u.
+func derive(e Expression, x string) (Expression, error) {
+	if !DependsOn(e, x) {
+		return zero(), nil
+	}
+	switch v := e.(type) {
+	case *literalExpression:
+		// the Expression depends on x, so v is x
+		return one(), nil
+	case *negation:
+		d, err := derive(v.Left, x)
+		if err != nil {
+			return nil, err
+		}
+		return neg(d), nil
+	case *degree:
+		d, err := derive(v.Left, x)
+		if err != nil {
+			return nil, err
+		}
+		return Degrees(d), nil
+	case *addition:
+		l, r, err := deriveLeftRight(v.Left, v.Right, x)
+		if err != nil {
+			return nil, err
+		}
+		return sum(l, r), nil
+	case *multiplication:
+		// (uv)' = u'v + uv'
+		l, r, err := deriveLeftRight(v.Left, v.Right, x)
+		if err != nil {
+			return nil, err
+		}
+		return sum(prod(l, v.Right), prod(v.Left, r)), nil
+	case *division:
+		// (u/v)' = (u'v - uv')/v^2
+		l, r, err := deriveLeftRight(v.Left, v.Right, x)
+		if err != nil {
+			return nil, err
+		}
+		if !DependsOn(v.Right, x) {
+			return quot(l, v.Right), nil
+		}
+		return quot(diff(prod(l, v.Right), prod(v.Left, r)), power(v.Right, ConstComplex(math.IntToComplex(2)))), nil
+	case *pow:
+		return derivePow(v, x)
+	case *predefinedFunction:
+		return deriveFunction(v, x)
+	case *piecewise:
+		values := make([]Expression, len(v.Values))
+		for i, val := range v.Values {
+			d, err := derive(val, x)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = d
+		}
+		otherwise, err := derive(v.Otherwise, x)
+		if err != nil {
+			return nil, err
+		}
+		return Piecewise(v.Conditions, values, otherwise), nil
+	}
+	return nil, errors.Join(ErrNotDerivable, fmt.Errorf("%s cannot be derived", String(e)))
+}
+
+func deriveLeftRight(left, right Expression, x string) (Expression, Expression, error) {
+	l, err := derive(left, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := derive(right, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+func derivePow(e *pow, x string) (Expression, error) {
+	u, v := e.Left, e.Right
+	du, dv, err := deriveLeftRight(u, v, x)
+	if err != nil {
+		return nil, err
+	}
+	if !DependsOn(v, x) {
+		// (u^n)' = n u^(n-1) u'
+		return prod(prod(v, power(u, diff(v, one()))), du), nil
+	}
+	if !DependsOn(u, x) {
+		// (a^v)' = a^v ln(a) v'
+		if c, ok := u.(*predefinedVariable); ok && c.ID == "e" {
+			return prod(e, dv), nil
+		}
+		return prod(prod(e, call("ln", u)), dv), nil
+	}
+	// (u^v)' = u^v (v' ln(u) + v u'/u)
+	return prod(e, sum(prod(dv, call("ln", u)), quot(prod(v, du), u))), nil
+}
+
+func deriveFunction(f *predefinedFunction, x string) (Expression, error) {
+	if len(f.args) != 1 || f.fn.Relation == nil {
+		if f.fn.MultiDerivative == nil {
+			return nil, errors.Join(ErrNotDerivable, fmt.Errorf("%s cannot be derived", f.ID))
+		}
+		return f.fn.MultiDerivative(f.args, x)
+	}
+	if f.fn.Derivative == nil {
+		return nil, errors.Join(ErrNotDerivable, fmt.Errorf("%s cannot be derived", f.ID))
+	}
+	// f(u)' = f'(u) u'
+	du, err := derive(f.args[0], x)
+	if err != nil {
+		return nil, err
+	}
+	return prod(f.fn.Derivative(f.args[0]), du), nil
+}
+
+// call returns the call of the predefined function id
+func call(id string, args ...Expression) Expression {
+	return &predefinedFunction{id, DefaultRegistry.functions[id], args}
+}
+
+func zero() Expression {
+	return ConstComplex(math.NullComplex)
+}
+
+func one() Expression {
+	return ConstComplex(math.OneComplex)
+}
+
+// constant returns the value of e if it is a number
+func constant(e Expression) (*math.Complex, bool) {
+	c, ok := e.(*constExp)
+	if !ok {
+		return nil, false
+	}
+	return c.Value, true
+}
+
+func isConstant(e Expression, z *math.Complex) bool {
+	c, ok := constant(e)
+	return ok && c.Is(z)
+}
+
+// sum returns a + b without the null terms
+func sum(a, b Expression) Expression {
+	ca, okA := constant(a)
+	cb, okB := constant(b)
+	switch {
+	case okA && okB:
+		return ConstComplex(ca.Add(cb))
+	case okA && ca.IsNull():
+		return b
+	case okB && cb.IsNull():
+		return a
+	}
+	if n, ok := b.(*negation); ok && n.IsSingle() {
+		return diff(a, n.Left)
+	}
+	return Add(a, b)
+}
+
+// diff returns a - b without the null terms
+func diff(a, b Expression) Expression {
+	ca, okA := constant(a)
+	cb, okB := constant(b)
+	switch {
+	case okA && okB:
+		return ConstComplex(ca.Sub(cb))
+	case okA && ca.IsNull():
+		return neg(b)
+	case okB && cb.IsNull():
+		return a
+	}
+	if n, ok := b.(*negation); ok && n.IsSingle() {
+		return sum(a, n.Left)
+	}
+	return Sub(a, b)
+}
+
+// neg returns -a, folding the numbers and the double negations
+func neg(a Expression) Expression {
+	if c, ok := constant(a); ok {
+		return ConstComplex(c.Neg())
+	}
+	if n, ok := a.(*negation); ok && n.IsSingle() {
+		return n.Left
+	}
+	if m, ok := a.(*multiplication); ok {
+		if c, isConst := constant(m.Left); isConst {
+			return prod(ConstComplex(c.Neg()), m.Right)
+		}
+	}
+	return Neg(a)
+}
+
+// prod returns a*b without the factors 1, the numbers being written first
+func prod(a, b Expression) Expression {
+	ca, okA := constant(a)
+	cb, okB := constant(b)
+	switch {
+	case okA && okB:
+		return ConstComplex(ca.Mul(cb))
+	case okA && ca.IsNull(), okB && cb.IsNull():
+		return zero()
+	case okA && ca.Is(math.OneComplex):
+		return b
+	case okB && cb.Is(math.OneComplex):
+		return a
+	case okA && ca.Is(math.IntToComplex(-1)):
+		return neg(b)
+	case okB && cb.Is(math.IntToComplex(-1)):
+		return neg(a)
+	case okB:
+		return prod(b, a)
+	}
+	if n, ok := a.(*negation); ok && n.IsSingle() {
+		return neg(prod(n.Left, b))
+	}
+	if n, ok := b.(*negation); ok && n.IsSingle() {
+		return neg(prod(a, n.Left))
+	}
+	if d, ok := a.(*division); ok {
+		return quot(prod(d.Left, b), d.Right)
+	}
+	if d, ok := b.(*division); ok {
+		return quot(prod(a, d.Left), d.Right)
+	}
+	if m, ok := a.(*multiplication); ok {
+		if _, isConst := constant(m.Left); isConst {
+			// (2*u)*b is 2*u*b
+			return prod(m.Left, prod(m.Right, b))
+		}
+	}
+	if m, ok := b.(*multiplication); ok {
+		if c, isConst := constant(m.Left); isConst {
+			if okA {
+				return prod(ConstComplex(ca.Mul(c)), m.Right)
+			}
+			// the numbers are written first: a*(2*u) is 2*a*u
+			return prod(m.Left, prod(a, m.Right))
+		}
+	}
+	return Mul(a, b)
+}
+
+// quot returns a/b without the denominator 1
+func quot(a, b Expression) Expression {
+	ca, okA := constant(a)
+	cb, okB := constant(b)
+	switch {
+	case okA && ca.IsNull():
+		return zero()
+	case okB && cb.Is(math.OneComplex):
+		return a
+	case okA && okB && !cb.IsNull():
+		res, err := ca.Div(cb)
+		if err == nil {
+			return ConstComplex(res)
+		}
+	}
+	if n, ok := a.(*negation); ok && n.IsSingle() {
+		return neg(quot(n.Left, b))
+	}
+	if d, ok := a.(*division); ok {
+		return quot(d.Left, prod(d.Right, b))
+	}
+	if ma, ok := a.(*multiplication); ok && okB && !cb.IsNull() {
+		if c, isConst := constant(ma.Left); isConst {
+			// (2u)/4 is u/2
+			res, _ := c.Div(cb)
+			return prod(ConstComplex(res), ma.Right)
+		}
+	}
+	if ma, ok := a.(*multiplication); ok {
+		if mb, ok := b.(*multiplication); ok {
+			ca, okA := constant(ma.Left)
+			cb, okB := constant(mb.Left)
+			if okA && okB && !cb.IsNull() {
+				// (2u)/(4v) is u/(2v)
+				c, _ := ca.Div(cb)
+				return prod(ConstComplex(c), quot(ma.Right, mb.Right))
+			}
+		}
+	}
+	if l, ok := a.(*literalExpression); ok {
+		if r, ok := b.(*literalExpression); ok && *l == *r {
+			return one()
+		}
+	}
+	return Div(a, b)
+}
+
+// power returns a^b without the exponents 0 and 1
+func power(a, b Expression) Expression {
+	if isConstant(b, math.NullComplex) {
+		return one()
+	}
+	if isConstant(b, math.OneComplex) {
+		return a
+	}
+	ca, okA := constant(a)
+	cb, okB := constant(b)
+	if okA && okB {
+		res, err := ca.Exp(cb)
+		if err == nil {
+			return ConstComplex(res)
+		}
+	}
+	return Pow(a, b)
+}
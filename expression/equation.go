@@ -0,0 +1,33 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// Equation is the equality Left = Right between two Expression.
+// It cannot be evaluated: it must be solved.
+type Equation struct {
+	Left, Right Expression
+}
+
+func NewEquation(l, r Expression) *Equation {
+	return &Equation{l, r}
+}
+
+func (e *Equation) Eval(_ *Scope) (*math.Complex, error) {
+	return nil, errors.Join(ErrNotEvaluable, errors.New("an equation must be solved"))
+}
+
+func (e *Equation) RenderLatex() (string, priority, error) {
+	l, _, err := e.Left.RenderLatex()
+	if err != nil {
+		return "", equationPriority, err
+	}
+	r, _, err := e.Right.RenderLatex()
+	if err != nil {
+		return "", equationPriority, err
+	}
+	return fmt.Sprintf("%s = %s", l, r), equationPriority, nil
+}
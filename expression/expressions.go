@@ -11,11 +11,13 @@ var (
 	ErrUnknownOperation = errors.New("unknown operation")
 	// ErrNumberNotInSpace is thrown when the number is not in the definition space
 	ErrNumberNotInSpace = errors.New("number is not in the definition space")
+	// ErrNotEvaluable is thrown when the Expression cannot be evaluated, like an equation
+	ErrNotEvaluable = errors.New("expression cannot be evaluated")
 )
 
 type Expression interface {
-	// Eval the Expression
-	Eval() (*math.Fraction, error)
+	// Eval the Expression in the given Scope (can be nil)
+	Eval(*Scope) (*math.Complex, error)
 	// RenderLatex the Expression
 	RenderLatex() (string, priority, error)
 }
@@ -23,37 +25,58 @@ type Expression interface {
 type priority uint8
 
 const (
-	termPriority    priority = 0
-	factorPriority  priority = 1
-	expPriority     priority = 2
-	unaryPriority   priority = 3
-	literalPriority priority = 4
+	equationPriority   priority = 0
+	orPriority         priority = 1
+	andPriority        priority = 2
+	notPriority        priority = 3
+	comparisonPriority priority = 4
+	termPriority       priority = 5
+	factorPriority     priority = 6
+	expPriority        priority = 7
+	unaryPriority      priority = 8
+	literalPriority    priority = 9
 )
 
 type constExp struct {
-	Value *math.Fraction
+	Value *math.Complex
 }
 
 type variable struct {
-	ID        string
-	OmitSlash bool
+	ID    string
+	saved *savedVariable
 }
 
 type function struct {
-	ID  string
-	exp Expression
+	ID   string
+	fn   *mathFunction
+	args []Expression
 }
 
 func Const(f *math.Fraction) Expression {
-	return &constExp{f}
+	return &constExp{math.FractionToComplex(f)}
 }
 
-func (l *constExp) Eval() (*math.Fraction, error) {
+// ConstReal returns an Expression representing the given Real
+func ConstReal(r *math.Real) Expression {
+	return &constExp{math.RealToComplex(r)}
+}
+
+// ConstComplex returns an Expression representing the given Complex
+func ConstComplex(z *math.Complex) Expression {
+	return &constExp{z}
+}
+
+func (l *constExp) Eval(_ *Scope) (*math.Complex, error) {
 	return l.Value, nil
 }
 
 func (l *constExp) RenderLatex() (string, priority, error) {
-	return l.Value.String(), literalPriority, nil
+	s := l.Value.LaTeX()
+	if strings.Contains(s, " ") {
+		// 1 + \sqrt{2} is a sum
+		return s, termPriority, nil
+	}
+	return s, literalPriority, nil
 }
 
 func handleLatexParenthesis(s string, stringPriority, currentPriority priority) string {
@@ -0,0 +1,103 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+	"math/big"
+)
+
+var (
+	// IntegralPrecision is the number of decimal digits with which the integrals of an Expression are checked to
+	// converge
+	IntegralPrecision = 15
+)
+
+// integral is the definite integral of Body with respect to X between Lower and Upper.
+// X is bound in Body: it is not a variable of the integral.
+type integral struct {
+	Body         Expression
+	X            string
+	Lower, Upper Expression
+}
+
+// Integral returns the definite integral of body with respect to x between a and b, evaluated numerically
+func Integral(body Expression, x string, a, b Expression) Expression {
+	return &integral{body, x, a, b}
+}
+
+// Integrate returns the integral of e with respect to x between a and b, the other variables being evaluated in the
+// given Scope (can be nil).
+// The result is an irrational math.Real whose digits are computed by a quadrature when they are requested: it does
+// not depend on later changes of the Scope.
+// The quadrature is checked to converge with the given number of decimal digits.
+// Returns the integral and the estimate of the absolute error of the quadrature with this precision.
+// Returns ErrNumberNotInSpace if a bound or a value of e is not real, and the errors of math.NewIntegral.
+func Integrate(e Expression, x string, a, b Expression, s *Scope, precision int) (*math.Real, *big.Float, error) {
+	lower, upper, err := getLeftRight(a, b, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	ra, okA := lower.Real()
+	rb, okB := upper.Real()
+	if !okA || !okB {
+		return nil, nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("the bounds %s and %s must be real", lower, upper))
+	}
+	vars := s.Snapshot()
+	f := func(t *math.Fraction) (*math.Real, error) {
+		scope := NewScope(vars)
+		scope.SetFraction(x, t)
+		v, err := e.Eval(scope)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := v.Real()
+		if !ok {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not real, only real functions can be integrated", v))
+		}
+		return r, nil
+	}
+	name := fmt.Sprintf("integrate(%s, %s, %s, %s)", String(e), x, ra, rb)
+	// the integral depends on the values of its variables and on the angle unit
+	id := name + "[" + vars.AngleUnit().String()
+	for _, v := range Variables(e) {
+		if v == x {
+			continue
+		}
+		val, err := vars.Eval(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		id += fmt.Sprintf(";%s=%s", v, val)
+	}
+	latex, _, err := Integral(e, x, ConstReal(ra), ConstReal(rb)).RenderLatex()
+	if err != nil {
+		return nil, nil, err
+	}
+	return math.NewIntegral(f, ra, rb, precision, id+"]", name, latex)
+}
+
+func (i *integral) Eval(s *Scope) (*math.Complex, error) {
+	r, _, err := Integrate(i.Body, i.X, i.Lower, i.Upper, s, IntegralPrecision)
+	if err != nil {
+		return nil, err
+	}
+	return math.RealToComplex(r), nil
+}
+
+func (i *integral) RenderLatex() (string, priority, error) {
+	body, _, err := i.Body.RenderLatex()
+	if err != nil {
+		return "", factorPriority, err
+	}
+	cf := make(chan string)
+	cr := make(chan string)
+	cpl := make(chan priority)
+	cpr := make(chan priority)
+	getLatexLeftRight(cf, cr, cpl, cpr, i.Lower, i.Upper)
+	a := <-cf
+	b := <-cr
+	<-cpl
+	<-cpr
+	return fmt.Sprintf(`\int_{%s}^{%s} %s \, d%s`, a, b, body, i.X), factorPriority, nil
+}
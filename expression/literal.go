@@ -1,13 +1,13 @@
 package expression
 
 import (
-	"errors"
 	"fmt"
 	"github.com/nyttikord/gomath/math"
+	"strings"
 )
 
 type Literal interface {
-	Eval() (*math.Fraction, error)
+	Eval(*Scope) (*math.Complex, error)
 	RenderLatex() (string, priority, error)
 }
 
@@ -17,70 +17,75 @@ type predefinedFunction function
 
 type literalExpression string
 
-func (l *literalExpression) Eval() (*math.Fraction, error) {
-	return nil, errors.Join(ErrUnknownOperation, fmt.Errorf("literal operations not supported"))
+func (l *literalExpression) Eval(s *Scope) (*math.Complex, error) {
+	return s.Eval(string(*l))
 }
 
 func (l *literalExpression) RenderLatex() (string, priority, error) {
 	return string(*l), literalPriority, nil
 }
 
-func (v *predefinedVariable) Eval() (*math.Fraction, error) {
-	val, ok := predefinedVariables[v.ID]
-	if !ok {
-		return nil, errors.Join(GenErrUnknownVariable(v.ID), fmt.Errorf("undefined variable %s", v.ID))
-	}
-	return val.Val, nil
+func (v *predefinedVariable) Eval(_ *Scope) (*math.Complex, error) {
+	return v.saved.Val, nil
 }
 
 func (v *predefinedVariable) RenderLatex() (string, priority, error) {
-	_, ok := predefinedVariables[v.ID]
-	if !ok {
-		return "", literalPriority, errors.Join(GenErrUnknownVariable(v.ID), fmt.Errorf("undefined variable %s", v.ID))
-	}
-	if v.OmitSlash {
-		return v.ID, literalPriority, nil
-	}
-	return `\` + v.ID, literalPriority, nil
+	return v.saved.LaTeX, literalPriority, nil
 }
 
-func (f *predefinedFunction) Eval() (*math.Fraction, error) {
-	fn, ok := predefinedFunctions[f.ID]
-	if !ok {
-		return nil, errors.Join(GenErrUnknownVariable(f.ID), fmt.Errorf("undefined variable %s", f.ID))
+func (f *predefinedFunction) Eval(s *Scope) (*math.Complex, error) {
+	vals := make([]*math.Complex, len(f.args))
+	for i, a := range f.args {
+		val, err := a.Eval(s)
+		if err != nil {
+			return nil, err
+		}
+		if f.fn.AngleArguments {
+			val, err = s.AngleUnit().convert(val, Radian)
+			if err != nil {
+				return nil, err
+			}
+		}
+		vals[i] = val
 	}
-	val, err := f.exp.Eval()
-	if err != nil {
-		return nil, err
+	res, err := f.fn.Eval(vals...)
+	if err != nil || !f.fn.AngleResult {
+		return res, err
 	}
-	return fn.Eval(val)
+	return Radian.convert(res, s.AngleUnit())
 }
 
 func (f *predefinedFunction) RenderLatex() (string, priority, error) {
-	_, ok := predefinedFunctions[f.ID]
-	if !ok {
-		return "", literalPriority, errors.Join(GenErrUnknownVariable(f.ID), fmt.Errorf("undefined variable %s", f.ID))
+	vals := make([]string, len(f.args))
+	for i, a := range f.args {
+		val, _, err := a.RenderLatex()
+		if err != nil {
+			return "", literalPriority, err
+		}
+		vals[i] = val
 	}
-	val, _, err := f.exp.RenderLatex()
-	if err != nil {
-		return "", literalPriority, err
+	if len(vals) != 1 || f.fn.Relation == nil {
+		if f.fn.MultiLaTeX != nil {
+			return f.fn.MultiLaTeX(vals), literalPriority, nil
+		}
+	} else if f.fn.LaTeX != "" {
+		return fmt.Sprintf(f.fn.LaTeX, vals[0]), literalPriority, nil
 	}
-	return fmt.Sprintf(`\%s\left(%s\right)`, f.ID, val), literalPriority, nil
+	return fmt.Sprintf(`\%s\left(%s\right)`, f.ID, strings.Join(vals, ", ")), literalPriority, nil
 }
 
+// LiteralExpression returns the constant l of the DefaultRegistry, or the user-defined variable l
 func LiteralExpression(l string) (Literal, error) {
-	if IsPredefinedVariable(l) {
-		return LiteralVariable(l), nil
-	}
-	exp := literalExpression(l)
-	return &exp, nil
+	return DefaultRegistry.Literal(l), nil
 }
 
+// LiteralVariable returns the constant id of the DefaultRegistry
 func LiteralVariable(id string) Literal {
-	v := predefinedVariables[id]
-	return &predefinedVariable{id, v.OmitSlash}
+	return &predefinedVariable{id, DefaultRegistry.variables[id]}
 }
 
-func LiteralFunction(id string, exp Expression) Literal {
-	return &predefinedFunction{id, exp}
+// LiteralFunction returns the call of the function id of the DefaultRegistry with the given arguments.
+// Returns ErrInvalidArity if the function does not accept this number of arguments.
+func LiteralFunction(id string, args ...Expression) (Literal, error) {
+	return DefaultRegistry.Function(id, args...)
 }
@@ -4,64 +4,89 @@ import (
 	"errors"
 	"fmt"
 	m "github.com/nyttikord/gomath/math"
-	"math"
+	"strings"
 )
 
 var (
 	// ErrUnknownVariable is thrown when GoMath doesn't know the variable used
 	ErrUnknownVariable = errors.New("unknown variable")
+	// ErrInvalidArity is thrown when a function is called with a wrong number of arguments
+	ErrInvalidArity = errors.New("invalid number of arguments")
 )
 
 var (
-	predefinedVariables = map[string]*savedVariable{}
-	predefinedFunctions = map[string]*mathFunction{}
+	// binaryFunctions are the function forms of binary operators, like mod(a, b)
+	binaryFunctions = map[string]func(Expression, Expression) Operator{
+		"mod": Mod,
+	}
 )
 
 type savedVariable struct {
-	Val       *m.Fraction
-	OmitSlash bool
+	Val   *m.Complex
+	LaTeX string
 }
 
-type relation func(*m.Fraction) *m.Fraction
+type relation func(*m.Real) (*m.Real, error)
+
+type complexRelation func(*m.Complex) (*m.Complex, error)
+
+type multiRelation func([]*m.Complex) (*m.Complex, error)
 
 func init() {
-	addVar := func(n string, v float64, omitSlash bool) {
-		f, err := m.FloatToFraction(v)
-		if err != nil {
-			panic(err)
-		}
-		predefinedVariables[n] = &savedVariable{f, omitSlash}
-	}
-	predefinedVariables["pi"] = &savedVariable{m.Pi, false}
-	addVar("e", math.E, true)
-	addVar("phi", math.Phi, false)
+	DefaultRegistry.variables["pi"] = &savedVariable{m.RealToComplex(m.Pi), `\pi`}
+	DefaultRegistry.variables["e"] = &savedVariable{m.RealToComplex(m.E), "e"}
+	DefaultRegistry.variables["phi"] = &savedVariable{m.RealToComplex(m.Phi), `\phi`}
+	DefaultRegistry.variables["i"] = &savedVariable{m.I, "i"}
 
 	addFunc := func(n string, f *mathFunction) {
-		predefinedFunctions[n] = f
+		DefaultRegistry.functions[n] = f
 	}
-	createMathFunction := func(def m.Space, mathFunc func(float64) float64) *mathFunction {
-		var rel relation
-		rel = func(f *m.Fraction) *m.Fraction {
-			x, _ := f.Float()
-			result, err := m.FloatToFraction(mathFunc(x))
-			if err != nil {
-				panic(err)
-			}
-			return result
-		}
-
+	createMathFunction := func(def m.Space, rel relation) *mathFunction {
 		return &mathFunction{
 			Definition: def,
 			Relation:   rel,
+			MinArity:   1,
+			MaxArity:   1,
+		}
+	}
+	createMultiFunction := func(minArity, maxArity int, rel multiRelation) *mathFunction {
+		return &mathFunction{
+			MinArity: minArity,
+			MaxArity: maxArity,
+			Multi:    rel,
 		}
 	}
 
-	addFunc("exp", createMathFunction(&m.RealSet{}, math.Exp))
-	addFunc("sqrt", createMathFunction(&m.RealSet{}, math.Sqrt))
-	addFunc("sin", createMathFunction(&m.RealSet{}, math.Sin))
-	addFunc("cos", createMathFunction(&m.RealSet{}, math.Cos))
+	exp := createMathFunction(&m.RealSet{}, m.Exp)
+	exp.Complex = m.ExpComplex
+	exp.Derivative = func(u Expression) Expression {
+		return call("exp", u)
+	}
+	addFunc("exp", exp)
+	sqrt := createMathFunction(m.SpaceRPositive, m.Sqrt)
+	sqrt.Complex = m.SqrtComplex
+	sqrt.ExtendReals = true
+	sqrt.LaTeX = `\sqrt{%s}`
+	sqrt.Derivative = func(u Expression) Expression {
+		return quot(one(), prod(ConstComplex(m.IntToComplex(2)), call("sqrt", u)))
+	}
+	addFunc("sqrt", sqrt)
+	sin := createMathFunction(&m.RealSet{}, m.Sin)
+	sin.Complex = m.SinComplex
+	sin.Derivative = func(u Expression) Expression {
+		return call("cos", u)
+	}
+	sin.AngleArguments = true
+	addFunc("sin", sin)
+	cos := createMathFunction(&m.RealSet{}, m.Cos)
+	cos.Complex = m.CosComplex
+	cos.Derivative = func(u Expression) Expression {
+		return neg(call("sin", u))
+	}
+	cos.AngleArguments = true
+	addFunc("cos", cos)
 
-	piOverTwo, err := m.Pi.Div(m.IntToFraction(2))
+	piOverTwo, err := m.Pi.Div(m.IntToReal(2))
 	if err != nil {
 		panic(err)
 	}
@@ -82,37 +107,377 @@ func init() {
 		Period:     m.Pi,
 		CustomName: "] -pi/2 ; pi/2 [ mod pi",
 	}
-	addFunc("tan", createMathFunction(tanDef, math.Tan))
-	addFunc("ln", createMathFunction(m.SpaceRStar, math.Log))
-	addFunc("log2", createMathFunction(m.SpaceRStarPositive, math.Log2))
+	tan := createMathFunction(tanDef, m.Tan)
+	tan.Complex = m.TanComplex
+	tan.Derivative = func(u Expression) Expression {
+		return quot(one(), power(call("cos", u), ConstComplex(m.IntToComplex(2))))
+	}
+	tan.AngleArguments = true
+	addFunc("tan", tan)
+	sec := createMathFunction(tanDef, m.Sec)
+	sec.Derivative = func(u Expression) Expression {
+		return prod(call("sec", u), call("tan", u))
+	}
+	sec.AngleArguments = true
+	addFunc("sec", sec)
+	cotDef := &m.PeriodicInterval{
+		Interval: &m.RealInterval{
+			LowerBound: &m.IntervalBound{
+				Value:        m.NullReal,
+				IncludeValue: false,
+				Infinite:     false,
+			},
+			UpperBound: &m.IntervalBound{
+				Value:        m.Pi,
+				IncludeValue: false,
+				Infinite:     false,
+			},
+			CustomName: "",
+		},
+		Period:     m.Pi,
+		CustomName: "] 0 ; pi [ mod pi",
+	}
+	csc := createMathFunction(cotDef, m.Csc)
+	csc.Derivative = func(u Expression) Expression {
+		return neg(prod(call("csc", u), call("cot", u)))
+	}
+	csc.AngleArguments = true
+	addFunc("csc", csc)
+	cot := createMathFunction(cotDef, m.Cot)
+	cot.Derivative = func(u Expression) Expression {
+		return neg(quot(one(), power(call("sin", u), ConstComplex(m.IntToComplex(2)))))
+	}
+	cot.AngleArguments = true
+	addFunc("cot", cot)
+
+	unitInterval := func(include bool, name string) *m.RealInterval {
+		return &m.RealInterval{
+			LowerBound: &m.IntervalBound{
+				Value:        m.OneReal.Neg(),
+				IncludeValue: include,
+				Infinite:     false,
+			},
+			UpperBound: &m.IntervalBound{
+				Value:        m.OneReal,
+				IncludeValue: include,
+				Infinite:     false,
+			},
+			CustomName: name,
+		}
+	}
+	// sqrt(1 - u^2)
+	sqrtOneMinusSquare := func(u Expression) Expression {
+		return call("sqrt", diff(one(), power(u, ConstComplex(m.IntToComplex(2)))))
+	}
+	asin := createMathFunction(unitInterval(true, "[ -1 ; 1 ]"), m.Asin)
+	asin.LaTeX = `\arcsin\left(%s\right)`
+	asin.Derivative = func(u Expression) Expression {
+		return quot(one(), sqrtOneMinusSquare(u))
+	}
+	asin.AngleResult = true
+	addFunc("asin", asin)
+	acos := createMathFunction(unitInterval(true, "[ -1 ; 1 ]"), m.Acos)
+	acos.LaTeX = `\arccos\left(%s\right)`
+	acos.Derivative = func(u Expression) Expression {
+		return neg(quot(one(), sqrtOneMinusSquare(u)))
+	}
+	acos.AngleResult = true
+	addFunc("acos", acos)
+	atan := createMathFunction(&m.RealSet{}, m.Atan)
+	atan.LaTeX = `\arctan\left(%s\right)`
+	atan.Derivative = func(u Expression) Expression {
+		return quot(one(), sum(one(), power(u, ConstComplex(m.IntToComplex(2)))))
+	}
+	atan.AngleResult = true
+	addFunc("atan", atan)
+
+	sinh := createMathFunction(&m.RealSet{}, m.Sinh)
+	sinh.Derivative = func(u Expression) Expression {
+		return call("cosh", u)
+	}
+	addFunc("sinh", sinh)
+	cosh := createMathFunction(&m.RealSet{}, m.Cosh)
+	cosh.Derivative = func(u Expression) Expression {
+		return call("sinh", u)
+	}
+	addFunc("cosh", cosh)
+	tanh := createMathFunction(&m.RealSet{}, m.Tanh)
+	tanh.Derivative = func(u Expression) Expression {
+		return quot(one(), power(call("cosh", u), ConstComplex(m.IntToComplex(2))))
+	}
+	addFunc("tanh", tanh)
+	asinh := createMathFunction(&m.RealSet{}, m.Asinh)
+	asinh.LaTeX = `\operatorname{arsinh}\left(%s\right)`
+	asinh.Derivative = func(u Expression) Expression {
+		return quot(one(), call("sqrt", sum(power(u, ConstComplex(m.IntToComplex(2))), one())))
+	}
+	addFunc("asinh", asinh)
+	acosh := createMathFunction(&m.RealInterval{
+		LowerBound: &m.IntervalBound{
+			Value:        m.OneReal,
+			IncludeValue: true,
+			Infinite:     false,
+		},
+		UpperBound: &m.IntervalBound{
+			Infinite: true,
+			Positive: true,
+		},
+		CustomName: "[ 1 ; +inf [",
+	}, m.Acosh)
+	acosh.LaTeX = `\operatorname{arcosh}\left(%s\right)`
+	acosh.Derivative = func(u Expression) Expression {
+		return quot(one(), call("sqrt", diff(power(u, ConstComplex(m.IntToComplex(2))), one())))
+	}
+	addFunc("acosh", acosh)
+	atanh := createMathFunction(unitInterval(false, "] -1 ; 1 ["), m.Atanh)
+	atanh.LaTeX = `\operatorname{artanh}\left(%s\right)`
+	atanh.Derivative = func(u Expression) Expression {
+		return quot(one(), diff(one(), power(u, ConstComplex(m.IntToComplex(2)))))
+	}
+	addFunc("atanh", atanh)
+	ln := createMathFunction(m.SpaceRStarPositive, m.Ln)
+	ln.Complex = m.LnComplex
+	ln.ExtendReals = true
+	ln.Derivative = func(u Expression) Expression {
+		return quot(one(), u)
+	}
+	addFunc("ln", ln)
+	log2 := createMathFunction(m.SpaceRStarPositive, m.Log2)
+	log2.LaTeX = `\log_2\left(%s\right)`
+	log2.Derivative = func(u Expression) Expression {
+		return quot(one(), prod(u, call("ln", ConstComplex(m.IntToComplex(2)))))
+	}
+	addFunc("log2", log2)
 
-	log10 := createMathFunction(m.SpaceRStarPositive, math.Log10)
-	addFunc("log", log10)
+	log10 := createMathFunction(m.SpaceRStarPositive, m.Log10)
+	log10.LaTeX = `\log_{10}\left(%s\right)`
+	log10.Derivative = func(u Expression) Expression {
+		return quot(one(), prod(u, call("ln", ConstComplex(m.IntToComplex(10)))))
+	}
 	addFunc("log10", log10)
+	log := createMathFunction(m.SpaceRStarPositive, m.Log10)
+	log.LaTeX = log10.LaTeX
+	log.MaxArity = 2
+	log.Multi = realRelation("log", func(args []*m.Real) (*m.Real, error) {
+		return m.Log(args[0], args[1])
+	})
+	log.MultiLaTeX = func(args []string) string {
+		return fmt.Sprintf(`\log_{%s}\left(%s\right)`, args[0], args[1])
+	}
+	log.Derivative = log10.Derivative
+	log.MultiDerivative = func(args []Expression, x string) (Expression, error) {
+		// log(b, u) = ln(u)/ln(b)
+		return derive(Div(call("ln", args[1]), call("ln", args[0])), x)
+	}
+	addFunc("log", log)
+
+	maxFunc := createMultiFunction(2, -1, realRelation("max", func(args []*m.Real) (*m.Real, error) {
+		res := args[0]
+		for _, r := range args[1:] {
+			if r.GreaterThan(res) {
+				res = r
+			}
+		}
+		return res, nil
+	}))
+	addFunc("max", maxFunc)
+	minFunc := createMultiFunction(2, -1, realRelation("min", func(args []*m.Real) (*m.Real, error) {
+		res := args[0]
+		for _, r := range args[1:] {
+			if r.SmallerThan(res) {
+				res = r
+			}
+		}
+		return res, nil
+	}))
+	addFunc("min", minFunc)
+	gcd := createMultiFunction(2, -1, fractionRelation("gcd", (*m.Fraction).GCD))
+	addFunc("gcd", gcd)
+	lcm := createMultiFunction(2, -1, fractionRelation("lcm", (*m.Fraction).LCM))
+	lcm.MultiLaTeX = operatorLaTeX("lcm")
+	addFunc("lcm", lcm)
+	root := createMultiFunction(2, 2, func(args []*m.Complex) (*m.Complex, error) {
+		n, ok := args[0].Fraction()
+		if !ok || !n.IsInt() || n.Sign() <= 0 {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("root(%s, x): the index must be a positive integer", args[0]))
+		}
+		i, _ := n.Int()
+		if !i.IsInt64() {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("root(%s, x): the index is too big", args[0]))
+		}
+		x, ok := args[1].Real()
+		if !ok {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("root(%s, %s): %s is not a real number", n, args[1], args[1]))
+		}
+		res, err := m.Root(i.Int64(), x)
+		if err != nil {
+			return nil, errors.Join(ErrNumberNotInSpace, err)
+		}
+		return m.RealToComplex(res), nil
+	})
+	root.MultiLaTeX = func(args []string) string {
+		return fmt.Sprintf(`\sqrt[%s]{%s}`, args[0], args[1])
+	}
+	root.MultiDerivative = func(args []Expression, x string) (Expression, error) {
+		// root(n, u) = u^(1/n)
+		return derive(Pow(args[1], quot(one(), args[0])), x)
+	}
+	addFunc("root", root)
+	atan2 := createMultiFunction(2, 2, realRelation("atan2", func(args []*m.Real) (*m.Real, error) {
+		return m.Atan2(args[0], args[1])
+	}))
+	atan2.MultiLaTeX = operatorLaTeX("atan2")
+	atan2.MultiDerivative = func(args []Expression, x string) (Expression, error) {
+		// atan2(y, x)' = (x y' - y x')/(x^2 + y^2)
+		y, z := args[0], args[1]
+		dy, dz, err := deriveLeftRight(y, z, x)
+		if err != nil {
+			return nil, err
+		}
+		return quot(diff(prod(z, dy), prod(y, dz)), sum(power(z, ConstComplex(m.IntToComplex(2))), power(y, ConstComplex(m.IntToComplex(2))))), nil
+	}
+	atan2.AngleResult = true
+	addFunc("atan2", atan2)
+}
+
+// realRelation returns a multiRelation calling rel with real arguments
+func realRelation(id string, rel func([]*m.Real) (*m.Real, error)) multiRelation {
+	return func(args []*m.Complex) (*m.Complex, error) {
+		reals := make([]*m.Real, len(args))
+		for i, z := range args {
+			r, ok := z.Real()
+			if !ok {
+				return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s: %s is not a real number", id, z))
+			}
+			reals[i] = r
+		}
+		res, err := rel(reals)
+		if err != nil {
+			return nil, errors.Join(ErrNumberNotInSpace, err)
+		}
+		return m.RealToComplex(res), nil
+	}
+}
+
+// fractionRelation returns a multiRelation folding the rational arguments with op
+func fractionRelation(id string, op func(*m.Fraction, *m.Fraction) (*m.Fraction, error)) multiRelation {
+	return func(args []*m.Complex) (*m.Complex, error) {
+		var res *m.Fraction
+		for _, z := range args {
+			f, ok := z.Fraction()
+			if !ok {
+				return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s: %s is not a rational number", id, z))
+			}
+			if res == nil {
+				res = f
+				continue
+			}
+			var err error
+			res, err = op(res, f)
+			if err != nil {
+				return nil, errors.Join(ErrNumberNotInSpace, err)
+			}
+		}
+		return m.FractionToComplex(res), nil
+	}
+}
+
+// operatorLaTeX returns the LaTeX renderer of a function unknown by LaTeX, like \operatorname{lcm}\left(a, b\right)
+func operatorLaTeX(id string) func([]string) string {
+	return func(args []string) string {
+		return fmt.Sprintf(`\operatorname{%s}\left(%s\right)`, id, strings.Join(args, ", "))
+	}
 }
 
 type mathFunction struct {
+	// Definition is the set of real numbers where Relation is defined
 	Definition m.Space
 	Relation   relation
+	// Complex extends the function to the complex plane (nil if the function is only defined for real numbers)
+	Complex complexRelation
+	// ExtendReals is true if Complex is also defined for the real numbers outside Definition, like sqrt(-1)
+	ExtendReals bool
+	// LaTeX is the format used to render the function, the argument replaces %s (\id\left(%s\right) if empty)
+	LaTeX string
+	// MinArity and MaxArity are the numbers of arguments accepted (MaxArity is negative if there is no limit)
+	MinArity, MaxArity int
+	// Multi is called when the function is not called with one argument, like max(a, b, c)
+	Multi multiRelation
+	// MultiLaTeX renders the function called with several arguments (\id\left(a, b\right) if nil)
+	MultiLaTeX func([]string) string
+	// Derivative returns f'(u), where f is the function of one argument (nil if f is not derivable)
+	Derivative func(u Expression) Expression
+	// MultiDerivative returns the derivative with respect to x of the function called with several arguments (nil if
+	// it is not derivable)
+	MultiDerivative func(args []Expression, x string) (Expression, error)
+	// AngleArguments is true if the arguments are angles, measured in the AngleUnit of the Scope
+	AngleArguments bool
+	// AngleResult is true if the result is an angle, measured in the AngleUnit of the Scope
+	AngleResult bool
 }
 
-func (mf *mathFunction) Eval(f *m.Fraction) (*m.Fraction, error) {
-	if !mf.Definition.Contains(f) {
-		return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not in %s", f, mf.Definition))
+func (mf *mathFunction) Eval(args ...*m.Complex) (*m.Complex, error) {
+	if len(args) != 1 || mf.Relation == nil {
+		return mf.Multi(args)
 	}
-	return mf.Relation(f), nil
+	z := args[0]
+	if r, ok := z.Real(); ok {
+		if mf.Definition.Contains(r) {
+			res, err := mf.Relation(r)
+			if err != nil {
+				return nil, err
+			}
+			return m.RealToComplex(res), nil
+		}
+		if mf.Complex == nil || !mf.ExtendReals {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not in %s", r, mf.Definition))
+		}
+	} else if mf.Complex == nil {
+		return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not a real number", z))
+	}
+	return mf.Complex(z)
 }
 
+// checkArity returns ErrInvalidArity if the function id cannot be called with n arguments
+func (mf *mathFunction) checkArity(id string, n int) error {
+	if n >= mf.MinArity && (mf.MaxArity < 0 || n <= mf.MaxArity) {
+		return nil
+	}
+	excepted := fmt.Sprintf("%d to %d arguments", mf.MinArity, mf.MaxArity)
+	switch {
+	case mf.MaxArity < 0:
+		excepted = fmt.Sprintf("at least %d arguments", mf.MinArity)
+	case mf.MaxArity == 1:
+		excepted = "1 argument"
+	case mf.MinArity == mf.MaxArity:
+		excepted = fmt.Sprintf("%d arguments", mf.MinArity)
+	case mf.MinArity+1 == mf.MaxArity:
+		excepted = fmt.Sprintf("%d or %d arguments", mf.MinArity, mf.MaxArity)
+	}
+	return errors.Join(ErrInvalidArity, fmt.Errorf("%s excepts %s, got %d", id, excepted, n))
+}
+
+// IsPredefinedVariable returns true if id is a constant of the DefaultRegistry
 func IsPredefinedVariable(id string) bool {
-	_, ok := predefinedVariables[id]
-	return ok
+	return DefaultRegistry.IsConstant(id)
 }
 
+// IsPredefinedFunction returns true if id is a function of the DefaultRegistry
 func IsPredefinedFunction(id string) bool {
-	_, ok := predefinedFunctions[id]
+	return DefaultRegistry.IsFunction(id)
+}
+
+// IsBinaryFunction returns true if id is the function form of a binary operator, like mod
+func IsBinaryFunction(id string) bool {
+	_, ok := binaryFunctions[id]
 	return ok
 }
 
+// BinaryFunction returns the operator called by the function form id with the arguments l and r
+func BinaryFunction(id string, l, r Expression) Expression {
+	return binaryFunctions[id](l, r)
+}
+
 func GenErrUnknownVariable(name string) error {
 	return errors.Join(ErrUnknownVariable, fmt.Errorf("unknown %s", name))
 }
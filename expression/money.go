@@ -0,0 +1,253 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// currency is an ISO 4217 currency code, like EUR.
+// It is only evaluated by EvalMoney.
+type currency struct {
+	Code string
+}
+
+func (c *currency) Eval(s *Scope) (*math.Complex, error) {
+	return nil, errors.Join(math.ErrCurrencyMismatch, fmt.Errorf("%s is not a number: use the money mode", c.Code))
+}
+
+func (c *currency) RenderLatex() (string, priority, error) {
+	return `\mathrm{` + c.Code + `}`, literalPriority, nil
+}
+
+// HasCurrency returns true if the Expression uses a currency, so it must be evaluated with EvalMoney
+func HasCurrency(e Expression) bool {
+	if _, ok := e.(*currency); ok {
+		return true
+	}
+	for _, c := range children(e) {
+		if HasCurrency(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMoney returns true if the Expression uses a currency, directly or through a variable or a user-defined function
+func hasMoney(e Expression, s *Scope) bool {
+	return usesMoney(e, s, map[*userFunction]bool{})
+}
+
+// usesMoney is hasMoney, seen containing the user-defined functions already visited
+func usesMoney(e Expression, s *Scope, seen map[*userFunction]bool) bool {
+	switch v := e.(type) {
+	case *currency:
+		return true
+	case *literalExpression:
+		exp, def, ok := s.lookup(string(*v))
+		return ok && usesMoney(exp, def.parent, seen)
+	case *userCall:
+		if !seen[v.fn] {
+			seen[v.fn] = true
+			if usesMoney(v.fn.Body, v.fn.Scope, seen) {
+				return true
+			}
+		}
+	}
+	for _, c := range children(e) {
+		if usesMoney(c, s, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvalMoney evaluates the Expression as an amount of money, like 12.5 EUR + 3 USD.
+// The irrational numbers are rounded with the rounding set by Scope.SetMoneyRounding when they are multiplied by an
+// amount, like the 1.05^(1/12) of 100 EUR * 1.05^(1/12), or when they are the result.
+// The amounts of different currencies are converted with the rates, which can be nil if the expression uses only one
+// currency: the right operand is converted in the currency of the left one.
+// Returns math.ErrCurrencyMismatch if the amounts cannot be combined, like 1 EUR * 1 EUR, or if a function is applied
+// to an amount, math.ErrUnknownCurrency if a rate is missing and ErrNumberNotInSpace if a number is irrational and the
+// Scope does not have a rounding.
+func EvalMoney(e Expression, s *Scope, rates *math.RateTable) (*math.Money, error) {
+	if !hasMoney(e, s) {
+		z, err := e.Eval(s)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := z.Real()
+		if !ok {
+			return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not real: amounts must be real", z))
+		}
+		f, err := roundMoney(r, s)
+		if err != nil {
+			return nil, err
+		}
+		return math.NewMoney(f, ""), nil
+	}
+	switch v := e.(type) {
+	case *currency:
+		return math.NewMoney(math.OneFraction, v.Code), nil
+	case *literalExpression:
+		exp, def, _ := s.lookup(string(*v))
+		return EvalMoney(exp, def.parent, rates)
+	case *conversion:
+		c, ok := v.Unit.(*currency)
+		if !ok {
+			return nil, errors.Join(math.ErrCurrencyMismatch, fmt.Errorf("%s is not a currency", String(v.Unit)))
+		}
+		m, err := EvalMoney(v.Value, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		return rates.Convert(m, c.Code)
+	case *addition:
+		l, r, err := evalMoneyLeftRight(v.Left, v.Right, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		if !l.IsNumber() && !r.IsNumber() {
+			r, err = rates.Convert(r, l.Currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return l.Add(r)
+	case *negation:
+		m, err := EvalMoney(v.Left, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		return m.Neg(), nil
+	case *multiplication:
+		if !hasMoney(v.Left, s) {
+			return scaleMoney(v.Right, v.Left, false, s, rates)
+		}
+		if !hasMoney(v.Right, s) {
+			return scaleMoney(v.Left, v.Right, false, s, rates)
+		}
+		l, r, err := evalMoneyLeftRight(v.Left, v.Right, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		return l.Mul(r)
+	case *division:
+		if !hasMoney(v.Right, s) {
+			return scaleMoney(v.Left, v.Right, true, s, rates)
+		}
+		l, r, err := evalMoneyLeftRight(v.Left, v.Right, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		if !l.IsNumber() && !r.IsNumber() {
+			r, err = rates.Convert(r, l.Currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return l.Div(r)
+	case *pow:
+		l, r, err := evalMoneyLeftRight(v.Left, v.Right, s, rates)
+		if err != nil {
+			return nil, err
+		}
+		if !r.IsNumber() {
+			return nil, errors.Join(math.ErrCurrencyMismatch, fmt.Errorf("the exponent %s must be a number", r))
+		}
+		return l.Exp(r.Amount)
+	case *piecewise:
+		for i, c := range v.Conditions {
+			b, err := c.Test(s)
+			if err != nil {
+				return nil, err
+			}
+			if b {
+				return EvalMoney(v.Values[i], s, rates)
+			}
+		}
+		return EvalMoney(v.Otherwise, s, rates)
+	case *userCall:
+		call, err := v.callScope(s)
+		if err != nil {
+			return nil, err
+		}
+		for i, a := range v.args {
+			m, err := EvalMoney(a, s, rates)
+			if err != nil {
+				return nil, err
+			}
+			call.Set(v.fn.Params[i], moneyExpression(m))
+		}
+		return EvalMoney(v.fn.Body, call, rates)
+	}
+	return nil, errors.Join(math.ErrCurrencyMismatch, fmt.Errorf("%s cannot be applied to an amount", String(e)))
+}
+
+// scaleMoney returns amount*factor, or amount/factor if div is true, factor being a number without currency
+func scaleMoney(amount, factor Expression, div bool, s *Scope, rates *math.RateTable) (*math.Money, error) {
+	m, err := EvalMoney(amount, s, rates)
+	if err != nil {
+		return nil, err
+	}
+	z, err := factor.Eval(s)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := z.Real()
+	if !ok {
+		return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not real: amounts must be real", z))
+	}
+	if f, ok := r.Fraction(); ok {
+		if div {
+			return m.Div(math.NewMoney(f, ""))
+		}
+		return m.Mul(math.NewMoney(f, ""))
+	}
+	if div {
+		r, err = r.Inv()
+		if err != nil {
+			return nil, err
+		}
+	}
+	f, err := roundMoney(math.FractionToReal(m.Amount).Mul(r), s)
+	if err != nil {
+		return nil, err
+	}
+	return math.NewMoney(f, m.Currency), nil
+}
+
+// roundMoney returns the Real rounded with the rounding of the amounts of the Scope if it is irrational.
+// Returns ErrNumberNotInSpace if it is irrational and the Scope does not have a rounding.
+func roundMoney(r *math.Real, s *Scope) (*math.Fraction, error) {
+	if f, ok := r.Fraction(); ok {
+		return f, nil
+	}
+	if s == nil || s.money == nil {
+		return nil, errors.Join(
+			ErrNumberNotInSpace,
+			fmt.Errorf("%s is not rational: set the rounding of the amounts with SetMoneyRounding", r),
+		)
+	}
+	return r.Round(s.money.decimals, s.money.mode), nil
+}
+
+// moneyExpression returns an Expression evaluated by EvalMoney to m
+func moneyExpression(m *math.Money) Expression {
+	if m.IsNumber() {
+		return Const(m.Amount)
+	}
+	return Mul(Const(m.Amount), &currency{m.Currency})
+}
+
+func evalMoneyLeftRight(left, right Expression, s *Scope, rates *math.RateTable) (*math.Money, *math.Money, error) {
+	l, err := EvalMoney(left, s, rates)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := EvalMoney(right, s, rates)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
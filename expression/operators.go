@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"github.com/nyttikord/gomath/math"
 	"math/big"
+	"unicode"
 )
 
 type Operator interface {
-	Eval() (*math.Fraction, error)
+	Eval(*Scope) (*math.Complex, error)
 	RenderLatex() (string, priority, error)
 }
 
 type UnaryOperator interface {
-	Eval() (*math.Fraction, error)
+	Eval(*Scope) (*math.Complex, error)
 	RenderLatex() (string, priority, error)
 	IsSingle() bool
 }
@@ -40,17 +41,20 @@ type pow struct {
 	Left, Right Expression
 }
 
+type modulo struct {
+	Left, Right Expression
+}
+
 type factorial struct {
 	Left     Expression
 	isSingle bool
 }
 
-func (a *addition) Eval() (*math.Fraction, error) {
-	cf := make(chan *math.Fraction)
-	cr := make(chan *math.Fraction)
-	getLeftRight(cf, cr, a.Left, a.Right)
-	lf := <-cf
-	lr := <-cr
+func (a *addition) Eval(s *Scope) (*math.Complex, error) {
+	lf, lr, err := getLeftRight(a.Left, a.Right, s)
+	if err != nil {
+		return nil, err
+	}
 	return lf.Add(lr), nil
 }
 
@@ -73,8 +77,8 @@ func (a *addition) RenderLatex() (string, priority, error) {
 	return fmt.Sprintf("%s %s %s", lf, op, lr), termPriority, nil
 }
 
-func (n *negation) Eval() (*math.Fraction, error) {
-	lf, err := n.Left.Eval()
+func (n *negation) Eval(s *Scope) (*math.Complex, error) {
+	lf, err := n.Left.Eval(s)
 	if err != nil {
 		return nil, err
 	}
@@ -86,10 +90,12 @@ func (n *negation) RenderLatex() (string, priority, error) {
 	if err != nil {
 		return "", unaryPriority, err
 	}
-	s = handleLatexParenthesis(s, p, unaryPriority)
 	if !n.IsSingle() {
-		return s, literalPriority, nil
+		// right side of a subtraction: a - x \times y does not need parenthesis
+		return handleLatexParenthesis(s, p, factorPriority), literalPriority, nil
 	}
+	// -(a \times b) is (-a) \times b, so the products do not need parenthesis
+	s = handleLatexParenthesis(s, p, factorPriority)
 	return fmt.Sprintf("%s%s", "-", s), unaryPriority, nil
 }
 
@@ -97,12 +103,11 @@ func (n *negation) IsSingle() bool {
 	return n.isSingle
 }
 
-func (m *multiplication) Eval() (*math.Fraction, error) {
-	cf := make(chan *math.Fraction)
-	cr := make(chan *math.Fraction)
-	getLeftRight(cf, cr, m.Left, m.Right)
-	lf := <-cf
-	lr := <-cr
+func (m *multiplication) Eval(s *Scope) (*math.Complex, error) {
+	lf, lr, err := getLeftRight(m.Left, m.Right, s)
+	if err != nil {
+		return nil, err
+	}
 	return lf.Mul(lr), nil
 }
 
@@ -118,15 +123,25 @@ func (m *multiplication) RenderLatex() (string, priority, error) {
 	pr := <-cpr
 	lf = handleLatexParenthesis(lf, pf, factorPriority)
 	lr = handleLatexParenthesis(lr, pr, factorPriority)
+	if v, ok := m.Right.(*predefinedVariable); ok && v.ID == "i" && pf == literalPriority {
+		// complex numbers are written 4i and not 4 \times i
+		if unicode.IsLetter(rune(lf[len(lf)-1])) {
+			return lf + " i", factorPriority, nil
+		}
+		return lf + "i", factorPriority, nil
+	}
+	if isUnit(m.Right) {
+		// quantities are written 3\,\mathrm{m} and not 3 \times \mathrm{m}
+		return lf + `\,` + lr, factorPriority, nil
+	}
 	return fmt.Sprintf(`%s \times %s`, lf, lr), factorPriority, nil
 }
 
-func (m *division) Eval() (*math.Fraction, error) {
-	cf := make(chan *math.Fraction)
-	cr := make(chan *math.Fraction)
-	getLeftRight(cf, cr, m.Left, m.Right)
-	lf := <-cf
-	lr := <-cr
+func (m *division) Eval(s *Scope) (*math.Complex, error) {
+	lf, lr, err := getLeftRight(m.Left, m.Right, s)
+	if err != nil {
+		return nil, err
+	}
 	return lf.Div(lr)
 }
 
@@ -141,12 +156,11 @@ func (m *division) RenderLatex() (string, priority, error) {
 	return fmt.Sprintf(`\frac{%s}{%s}`, lf, lr), factorPriority, nil
 }
 
-func (e *pow) Eval() (*math.Fraction, error) {
-	cf := make(chan *math.Fraction)
-	cr := make(chan *math.Fraction)
-	getLeftRight(cf, cr, e.Left, e.Right)
-	lf := <-cf
-	lr := <-cr
+func (e *pow) Eval(s *Scope) (*math.Complex, error) {
+	lf, lr, err := getLeftRight(e.Left, e.Right, s)
+	if err != nil {
+		return nil, err
+	}
 	return lf.Exp(lr)
 }
 
@@ -160,7 +174,12 @@ func (e *pow) RenderLatex() (string, priority, error) {
 	lr := <-cr
 	pf := <-cpl
 	_ = <-cpr
-	s := handleLatexParenthesis(lf, pf, expPriority) + "^"
+	s := handleLatexParenthesis(lf, pf, expPriority)
+	if pf == expPriority {
+		// (x^2)^3 is not x^{2^3}
+		s = `\left(` + s + `\right)`
+	}
+	s += "^"
 	if len(lr) > 1 {
 		s += "{" + lr + "}"
 	} else {
@@ -169,13 +188,50 @@ func (e *pow) RenderLatex() (string, priority, error) {
 	return s, expPriority, nil
 }
 
-func (f *factorial) Eval() (*math.Fraction, error) {
-	lf, err := f.Left.Eval()
+func (m *modulo) Eval(s *Scope) (*math.Complex, error) {
+	lf, lr, err := getLeftRight(m.Left, m.Right, s)
+	if err != nil {
+		return nil, err
+	}
+	a, okA := lf.Real()
+	b, okB := lr.Real()
+	if !okA || !okB {
+		return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s mod %s is not defined for complex numbers", lf, lr))
+	}
+	res, err := a.Mod(b)
+	if err != nil {
+		return nil, err
+	}
+	return math.RealToComplex(res), nil
+}
+
+func (m *modulo) RenderLatex() (string, priority, error) {
+	cf := make(chan string)
+	cr := make(chan string)
+	cpl := make(chan priority)
+	cpr := make(chan priority)
+	getLatexLeftRight(cf, cr, cpl, cpr, m.Left, m.Right)
+	lf := <-cf
+	lr := <-cr
+	pf := <-cpl
+	pr := <-cpr
+	lf = handleLatexParenthesis(lf, pf, factorPriority)
+	// a \bmod b \times c is read (a \bmod b) \times c, so the right side needs parenthesis at the same priority
+	lr = handleLatexParenthesis(lr, pr, expPriority)
+	return fmt.Sprintf(`%s \bmod %s`, lf, lr), factorPriority, nil
+}
+
+func (f *factorial) Eval(s *Scope) (*math.Complex, error) {
+	lf, err := f.Left.Eval(s)
 	if err != nil {
 		return nil, err
 	}
 	var i *big.Int
-	if i, err = lf.Int(); err != nil || i.Cmp(math.NullBigInt) < 0 {
+	fr, ok := lf.Fraction()
+	if !ok {
+		return nil, errors.Join(ErrNumberNotInSpace, errors.New("factorial is not supported for non positive integer"))
+	}
+	if i, err = fr.Int(); err != nil || i.Cmp(math.NullBigInt) < 0 {
 		return nil, errors.Join(ErrNumberNotInSpace, errors.New("factorial is not supported for non positive integer"))
 	}
 	if !i.IsInt64() {
@@ -188,7 +244,7 @@ func (f *factorial) Eval() (*math.Fraction, error) {
 		res *= ii
 		ii--
 	}
-	return math.IntToFraction(res), nil
+	return math.IntToComplex(res), nil
 }
 
 func (f *factorial) RenderLatex() (string, priority, error) {
@@ -232,21 +288,26 @@ func Pow(l Expression, r Expression) Operator {
 	return &pow{l, r}
 }
 
-func getLeftRight(cl, cr chan<- *math.Fraction, left, right Expression) {
+func Mod(l Expression, r Expression) Operator {
+	return &modulo{l, r}
+}
+
+// getLeftRight evaluates left and right concurrently in the given Scope
+func getLeftRight(left, right Expression, s *Scope) (*math.Complex, *math.Complex, error) {
+	cl := make(chan *math.Complex)
+	cerr := make(chan error)
 	go func() {
-		lf, err := left.Eval()
+		lf, err := left.Eval(s)
+		cerr <- err
 		cl <- lf
-		if err != nil {
-			panic(err)
-		}
-	}()
-	go func() {
-		lr, err := right.Eval()
-		cr <- lr
-		if err != nil {
-			panic(err)
-		}
 	}()
+	lr, err := right.Eval(s)
+	errLeft := <-cerr
+	lf := <-cl
+	if errLeft != nil {
+		return nil, nil, errLeft
+	}
+	return lf, lr, err
 }
 
 func getLatexLeftRight(cl, cr chan<- string, cpl, cpr chan<- priority, left, right Expression) {
@@ -0,0 +1,63 @@
+package expression
+
+import (
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+	"strings"
+)
+
+// piecewise is evaluated to the value of the first true condition, or to Otherwise if every condition is false
+type piecewise struct {
+	Conditions []Condition
+	Values     []Expression
+	Otherwise  Expression
+}
+
+// If returns an Expression evaluated to a if c is true and to b otherwise.
+// Only the chosen branch is evaluated.
+func If(c Condition, a, b Expression) Expression {
+	return &piecewise{[]Condition{c}, []Expression{a}, b}
+}
+
+// Piecewise returns an Expression evaluated to the value of the first true condition, or to otherwise if every
+// condition is false.
+// conds and values must have the same length.
+// Only the chosen branch is evaluated.
+func Piecewise(conds []Condition, values []Expression, otherwise Expression) Expression {
+	return &piecewise{conds, values, otherwise}
+}
+
+func (p *piecewise) Eval(s *Scope) (*math.Complex, error) {
+	for i, c := range p.Conditions {
+		b, err := c.Test(s)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return p.Values[i].Eval(s)
+		}
+	}
+	return p.Otherwise.Eval(s)
+}
+
+func (p *piecewise) RenderLatex() (string, priority, error) {
+	var sb strings.Builder
+	sb.WriteString(`\begin{cases} `)
+	for i, c := range p.Conditions {
+		v, _, err := p.Values[i].RenderLatex()
+		if err != nil {
+			return "", literalPriority, err
+		}
+		cond, _, err := c.RenderLatex()
+		if err != nil {
+			return "", literalPriority, err
+		}
+		sb.WriteString(fmt.Sprintf(`%s & \text{if } %s \\ `, v, cond))
+	}
+	o, _, err := p.Otherwise.RenderLatex()
+	if err != nil {
+		return "", literalPriority, err
+	}
+	sb.WriteString(fmt.Sprintf(`%s & \text{otherwise} \end{cases}`, o))
+	return sb.String(), literalPriority, nil
+}
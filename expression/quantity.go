@@ -0,0 +1,200 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// unit is a unit of measurement, like km.
+// It is only evaluated by EvalQuantity.
+type unit struct {
+	ID   string
+	Unit *math.Unit
+}
+
+// conversion is the Quantity Value displayed in Unit, like convert(100 km/h, m/s)
+type conversion struct {
+	Value, Unit Expression
+}
+
+// Convert returns the conversion of the quantity v in the unit u
+func Convert(v, u Expression) Expression {
+	return &conversion{v, u}
+}
+
+// numberOf returns the Complex of the Quantity, which must be dimensionless
+func numberOf(q *math.Quantity) (*math.Complex, error) {
+	if !q.IsDimensionless() {
+		return nil, errors.Join(math.ErrDimensionMismatch, fmt.Errorf("%s is not a number: use a quantity", q))
+	}
+	return math.FractionToComplex(q.Value), nil
+}
+
+func (u *unit) Eval(s *Scope) (*math.Complex, error) {
+	return numberOf(u.Unit.Quantity())
+}
+
+func (u *unit) RenderLatex() (string, priority, error) {
+	return u.Unit.LaTeX, literalPriority, nil
+}
+
+func (c *conversion) Eval(s *Scope) (*math.Complex, error) {
+	q, err := EvalQuantity(c, s)
+	if err != nil {
+		return nil, err
+	}
+	return numberOf(q)
+}
+
+func (c *conversion) RenderLatex() (string, priority, error) {
+	cf := make(chan string)
+	cr := make(chan string)
+	cpl := make(chan priority)
+	cpr := make(chan priority)
+	getLatexLeftRight(cf, cr, cpl, cpr, c.Value, c.Unit)
+	v := <-cf
+	u := <-cr
+	<-cpl
+	<-cpr
+	return fmt.Sprintf(`\operatorname{convert}\left(%s, %s\right)`, v, u), literalPriority, nil
+}
+
+// isUnit returns true if the Expression is only composed of units, like m/s^2
+func isUnit(e Expression) bool {
+	switch v := e.(type) {
+	case *unit, *currency:
+		return true
+	case *pow:
+		return isUnit(v.Left)
+	case *multiplication:
+		return isUnit(v.Left) && isUnit(v.Right)
+	case *division:
+		return isUnit(v.Left) && isUnit(v.Right)
+	}
+	return false
+}
+
+// HasUnit returns true if the Expression uses a unit, so it must be evaluated with EvalQuantity
+func HasUnit(e Expression) bool {
+	switch e.(type) {
+	case *unit, *conversion:
+		return true
+	}
+	for _, c := range children(e) {
+		if HasUnit(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvalQuantity evaluates the Expression as a physical quantity, like 3 m + 20 cm.
+// The numbers must be rational.
+// Returns math.ErrDimensionMismatch if the units are not compatible, like 1 m + 1 s, or if a function is applied to a
+// quantity having a unit.
+func EvalQuantity(e Expression, s *Scope) (*math.Quantity, error) {
+	switch v := e.(type) {
+	case *unit:
+		return v.Unit.Quantity(), nil
+	case *conversion:
+		q, err := EvalQuantity(v.Value, s)
+		if err != nil {
+			return nil, err
+		}
+		u, err := EvalQuantity(v.Unit, s)
+		if err != nil {
+			return nil, err
+		}
+		latex, _, err := v.Unit.RenderLatex()
+		if err != nil {
+			return nil, err
+		}
+		return q.In(&math.Unit{Name: String(v.Unit), LaTeX: latex, Factor: u.Value, Dim: u.Dim})
+	case *addition:
+		l, r, err := evalQuantityLeftRight(v.Left, v.Right, s)
+		if err != nil {
+			return nil, err
+		}
+		return l.Add(r)
+	case *negation:
+		q, err := EvalQuantity(v.Left, s)
+		if err != nil {
+			return nil, err
+		}
+		return q.Neg(), nil
+	case *multiplication:
+		l, r, err := evalQuantityLeftRight(v.Left, v.Right, s)
+		if err != nil {
+			return nil, err
+		}
+		return l.Mul(r), nil
+	case *division:
+		l, r, err := evalQuantityLeftRight(v.Left, v.Right, s)
+		if err != nil {
+			return nil, err
+		}
+		return l.Div(r)
+	case *pow:
+		l, r, err := evalQuantityLeftRight(v.Left, v.Right, s)
+		if err != nil {
+			return nil, err
+		}
+		if !r.IsDimensionless() {
+			return nil, errors.Join(math.ErrDimensionMismatch, fmt.Errorf("the exponent %s must be a number", r))
+		}
+		return l.Exp(r.Value)
+	case *predefinedFunction:
+		if n, ok := v.rootIndex(s); ok {
+			q, err := EvalQuantity(v.args[len(v.args)-1], s)
+			if err != nil {
+				return nil, err
+			}
+			return q.Exp(math.NewFraction(1, n))
+		}
+	}
+	if HasUnit(e) {
+		return nil, errors.Join(math.ErrDimensionMismatch, fmt.Errorf("%s cannot be applied to a quantity", String(e)))
+	}
+	z, err := e.Eval(s)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := z.Fraction()
+	if !ok {
+		return nil, errors.Join(ErrNumberNotInSpace, fmt.Errorf("%s is not rational: quantities must be rational", z))
+	}
+	return math.NewQuantity(f, math.Dimension{}), nil
+}
+
+// rootIndex returns n if the function is the predefined sqrt or root(n, x), so it can be applied to a quantity like
+// sqrt(4 m^2)
+func (f *predefinedFunction) rootIndex(s *Scope) (int64, bool) {
+	switch {
+	case f.ID == "sqrt" && f.fn == DefaultRegistry.functions["sqrt"]:
+		return 2, true
+	case f.ID == "root" && f.fn == DefaultRegistry.functions["root"] && len(f.args) == 2:
+		z, err := f.args[0].Eval(s)
+		if err != nil {
+			return 0, false
+		}
+		n, ok := z.Fraction()
+		if !ok || !n.IsInt() || n.Sign() <= 0 || !n.Num().IsInt64() {
+			return 0, false
+		}
+		return n.Num().Int64(), true
+	}
+	return 0, false
+}
+
+func evalQuantityLeftRight(left, right Expression, s *Scope) (*math.Quantity, *math.Quantity, error) {
+	l, err := EvalQuantity(left, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := EvalQuantity(right, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
@@ -0,0 +1,194 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	m "github.com/nyttikord/gomath/math"
+	"maps"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidName is thrown when a constant or a function cannot be registered with the given name
+	ErrInvalidName = errors.New("invalid name")
+	// ErrInvalidDefinition is thrown when a constant or a function is registered without its value or its relation
+	ErrInvalidDefinition = errors.New("invalid definition")
+
+	// DefaultRegistry contains the constants and the functions predefined by GoMath.
+	// Use Clone to extend it without modifying the parsing of other expressions.
+	DefaultRegistry = NewRegistry()
+)
+
+// Registry holds the constants and the functions known by the parser
+type Registry struct {
+	variables map[string]*savedVariable
+	functions map[string]*mathFunction
+	users     map[string]*userFunction
+	// units is true if the literals are resolved as units of measurement, like km
+	units bool
+	// money is true if the ISO 4217 currency codes are resolved as currencies, like EUR
+	money bool
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		variables: map[string]*savedVariable{},
+		functions: map[string]*mathFunction{},
+		users:     map[string]*userFunction{},
+	}
+}
+
+// Clone returns a copy of the Registry: the constants and the functions added to the copy are not added to the
+// original one
+func (r *Registry) Clone() *Registry {
+	return &Registry{
+		variables: maps.Clone(r.variables),
+		functions: maps.Clone(r.functions),
+		users:     maps.Clone(r.users),
+		units:     r.units,
+		money:     r.money,
+	}
+}
+
+// SetUnits enables or disables the units of measurement: if they are enabled, the literals which are not constants
+// are resolved as SI units with an optional prefix, like km or mA, before being resolved as variables
+func (r *Registry) SetUnits(enabled bool) {
+	r.units = enabled
+}
+
+// HasUnits returns true if the units of measurement are enabled
+func (r *Registry) HasUnits() bool {
+	return r.units
+}
+
+// SetMoney enables or disables the currencies: if they are enabled, the literals which are not constants and have the
+// shape of an ISO 4217 code, like EUR, are resolved as currencies before being resolved as units or variables
+func (r *Registry) SetMoney(enabled bool) {
+	r.money = enabled
+}
+
+// HasMoney returns true if the currencies are enabled
+func (r *Registry) HasMoney() bool {
+	return r.money
+}
+
+// SetConstant registers the constant name.
+// latex is its LaTeX representation, like \mathrm{g} (name if empty).
+// Returns ErrInvalidName if name is not only composed of letters or if it is already used by a function, and
+// ErrInvalidDefinition if value is nil.
+func (r *Registry) SetConstant(name string, value *m.Complex, latex string) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.Join(ErrInvalidDefinition, fmt.Errorf("the value of %s is nil", name))
+	}
+	if r.IsFunction(name) {
+		return errors.Join(ErrInvalidName, fmt.Errorf("%s is already a function", name))
+	}
+	if latex == "" {
+		latex = name
+	}
+	r.variables[name] = &savedVariable{value, latex}
+	return nil
+}
+
+// SetFunction registers the function name of one argument, defined on domain (R if nil).
+// latex is the LaTeX name of the function, like \operatorname{vat} (\operatorname{name} if empty).
+// Returns ErrInvalidName if name is not only composed of letters or if it is already used by a constant, and
+// ErrInvalidDefinition if fn is nil.
+func (r *Registry) SetFunction(name string, domain m.Space, fn func(*m.Real) (*m.Real, error), latex string) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+	if fn == nil {
+		return errors.Join(ErrInvalidDefinition, fmt.Errorf("the relation of %s is nil", name))
+	}
+	if domain == nil {
+		domain = &m.RealSet{}
+	}
+	if _, ok := r.variables[name]; ok {
+		return errors.Join(ErrInvalidName, fmt.Errorf("%s is already a constant", name))
+	}
+	if latex == "" {
+		latex = `\operatorname{` + name + "}"
+	}
+	delete(r.users, name)
+	r.functions[name] = &mathFunction{
+		Definition: domain,
+		Relation:   fn,
+		LaTeX:      strings.ReplaceAll(latex, "%", "%%") + `\left(%s\right)`,
+		MinArity:   1,
+		MaxArity:   1,
+	}
+	return nil
+}
+
+func (r *Registry) checkName(name string) error {
+	if name == "" {
+		return errors.Join(ErrInvalidName, errors.New("empty name"))
+	}
+	for _, c := range name {
+		if !unicode.IsLetter(c) {
+			return errors.Join(ErrInvalidName, fmt.Errorf("%s must only contain letters", name))
+		}
+	}
+	if IsBinaryFunction(name) {
+		return errors.Join(ErrInvalidName, fmt.Errorf("%s is an operator", name))
+	}
+	return nil
+}
+
+// IsConstant returns true if id is a constant of the Registry, like pi
+func (r *Registry) IsConstant(id string) bool {
+	_, ok := r.variables[id]
+	return ok
+}
+
+// IsFunction returns true if id is a function of the Registry or the function form of an operator, like sqrt or mod
+func (r *Registry) IsFunction(id string) bool {
+	_, ok := r.functions[id]
+	_, isUser := r.users[id]
+	return ok || isUser || IsBinaryFunction(id)
+}
+
+// Literal returns the constant id if it is in the Registry, the currency id if the currencies are enabled, the unit id
+// if the units are enabled, or the user-defined variable id otherwise
+func (r *Registry) Literal(id string) Literal {
+	if v, ok := r.variables[id]; ok {
+		return &predefinedVariable{id, v}
+	}
+	if r.money && m.IsCurrencyCode(id) {
+		return &currency{id}
+	}
+	if u, ok := m.ParseUnit(id); ok && r.units {
+		return &unit{id, u}
+	}
+	exp := literalExpression(id)
+	return &exp
+}
+
+// Function returns the call of the function id with the given arguments.
+// Returns ErrUnknownVariable if the function is not in the Registry and ErrInvalidArity if it does not accept this
+// number of arguments.
+func (r *Registry) Function(id string, args ...Expression) (Literal, error) {
+	if user, ok := r.users[id]; ok {
+		if len(args) != len(user.Params) {
+			return nil, errors.Join(
+				ErrInvalidArity,
+				fmt.Errorf("%s excepts %d arguments, got %d", id, len(user.Params), len(args)),
+			)
+		}
+		return &userCall{id, user, args}, nil
+	}
+	fn, ok := r.functions[id]
+	if !ok {
+		return nil, GenErrUnknownVariable(id)
+	}
+	if err := fn.checkArity(id, len(args)); err != nil {
+		return nil, err
+	}
+	return &predefinedFunction{id, fn, args}, nil
+}
@@ -0,0 +1,140 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+)
+
+// Scope holds the user-defined variables available during the evaluation of an Expression.
+//
+// A variable is either bound to a number or to a sub-Expression.
+// A sub-Expression is evaluated in the parent of the Scope defining it, so a binding can refer to an outer variable
+// having the same name.
+type Scope struct {
+	parent *Scope
+	values map[string]Expression
+	// depth is the number of nested calls of user-defined functions
+	depth int
+	// angle is the unit of the angles used by the trigonometric functions
+	angle AngleUnit
+	// rates are the conversion rates of the currencies compared in the Scope
+	rates *math.RateTable
+	// money is the rounding of the irrational numbers used by the amounts (nil if they are rejected)
+	money *moneyRounding
+}
+
+// moneyRounding is the rounding of the irrational numbers used by the amounts
+type moneyRounding struct {
+	decimals int
+	mode     math.RoundingMode
+}
+
+// NewScope creates a new Scope inheriting every variable of parent.
+// parent can be nil.
+func NewScope(parent *Scope) *Scope {
+	s := &Scope{parent: parent, values: map[string]Expression{}}
+	if parent != nil {
+		s.depth = parent.depth
+		s.angle = parent.angle
+		s.rates = parent.rates
+		s.money = parent.money
+	}
+	return s
+}
+
+// SetAngleUnit sets the unit of the angles used by the trigonometric functions evaluated in the Scope
+func (s *Scope) SetAngleUnit(u AngleUnit) {
+	s.angle = u
+}
+
+// AngleUnit returns the unit of the angles used by the trigonometric functions (Radian if the Scope is nil)
+func (s *Scope) AngleUnit() AngleUnit {
+	if s == nil {
+		return Radian
+	}
+	return s.angle
+}
+
+// SetRates sets the conversion rates of the currencies compared in the Scope
+func (s *Scope) SetRates(r *math.RateTable) {
+	s.rates = r
+}
+
+// Rates returns the conversion rates of the currencies compared in the Scope (nil if the Scope is nil)
+func (s *Scope) Rates() *math.RateTable {
+	if s == nil {
+		return nil
+	}
+	return s.rates
+}
+
+// SetMoneyRounding sets the rounding of the irrational numbers used by the amounts evaluated in the Scope by
+// EvalMoney, like the 1.05^(1/12) of 100 EUR * 1.05^(1/12)
+func (s *Scope) SetMoneyRounding(decimals int, mode math.RoundingMode) {
+	s.money = &moneyRounding{decimals, mode}
+}
+
+// Set binds the variable name to the given Expression
+func (s *Scope) Set(name string, exp Expression) {
+	s.values[name] = exp
+}
+
+// SetFraction binds the variable name to the given Fraction
+func (s *Scope) SetFraction(name string, f *math.Fraction) {
+	s.Set(name, Const(f))
+}
+
+// SetReal binds the variable name to the given Real
+func (s *Scope) SetReal(name string, r *math.Real) {
+	s.Set(name, ConstReal(r))
+}
+
+// SetComplex binds the variable name to the given Complex
+func (s *Scope) SetComplex(name string, z *math.Complex) {
+	s.Set(name, ConstComplex(z))
+}
+
+// Has returns true if the variable name is defined in the Scope or in one of its parents
+func (s *Scope) Has(name string) bool {
+	_, _, ok := s.lookup(name)
+	return ok
+}
+
+// Eval the variable name
+func (s *Scope) Eval(name string) (*math.Complex, error) {
+	exp, def, ok := s.lookup(name)
+	if !ok {
+		return nil, errors.Join(GenErrUnknownVariable(name), fmt.Errorf("undefined variable %s", name))
+	}
+	return exp.Eval(def.parent)
+}
+
+// lookup returns the Expression bound to name and the Scope defining it
+func (s *Scope) lookup(name string) (Expression, *Scope, bool) {
+	for c := s; c != nil; c = c.parent {
+		if exp, ok := c.values[name]; ok {
+			return exp, c, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Snapshot returns a Scope binding every variable visible in s to its current value, so it does not depend on later
+// changes of s.
+// The variables which cannot be evaluated are not bound.
+func (s *Scope) Snapshot() *Scope {
+	c := NewScope(s)
+	c.parent = nil
+	for p := s; p != nil; p = p.parent {
+		for name := range p.values {
+			if _, ok := c.values[name]; ok {
+				continue
+			}
+			if z, err := s.Eval(name); err == nil {
+				c.SetComplex(name, z)
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,420 @@
+package expression
+
+import (
+	"github.com/nyttikord/gomath/math"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// monomial is the product coef * base_1^exp_1 * ... * base_n^exp_n, the factors being sorted by key
+type monomial struct {
+	coef    *math.Complex
+	factors []*monomialFactor
+}
+
+// monomialFactor is the factor base^exp of a monomial, key being the plain text representation of base
+type monomialFactor struct {
+	base Expression
+	key  string
+	exp  *math.Fraction
+}
+
+// Simplify returns an Expression equal to e written in a simpler form:
+//   - the nested sums and products are flattened and the numbers are folded, like 2*3 + 1 is 7;
+//   - the like terms are collected, like 2x + 3x - x is 4x;
+//   - the powers of a same base are merged, like x*x^2 is x^3 and (x^2)^3 is x^6;
+//   - the common factors of a division are cancelled, like 6x^2/(4x) is 3x/2;
+//   - the negations are pulled in front of the terms, like -(-x) is x.
+//
+// The sums are not expanded: (x + 1)^2 is kept as is.
+func Simplify(e Expression) Expression {
+	switch v := e.(type) {
+	case *addition, *negation, *multiplication, *division, *pow:
+		return rebuild(terms(e))
+	case *predefinedFunction:
+		args := simplifyAll(v.args)
+		return fold(&predefinedFunction{v.ID, v.fn, args}, args)
+	case *userCall:
+		args := simplifyAll(v.args)
+		return fold(&userCall{v.ID, v.fn, args}, args)
+	case *modulo:
+		args := simplifyAll([]Expression{v.Left, v.Right})
+		return fold(Mod(args[0], args[1]), args)
+	case *factorial:
+		l := Simplify(v.Left)
+		return fold(Factorial(l), []Expression{l})
+	case *degree:
+		// the value of the angle depends on the AngleUnit, so it is not folded
+		return Degrees(Simplify(v.Left))
+	case *Equation:
+		return NewEquation(Simplify(v.Left), Simplify(v.Right))
+	case Condition:
+		return simplifyCondition(v)
+	case *piecewise:
+		return simplifyPiecewise(v)
+	case *integral:
+		// the integral is not evaluated: its value is approximated
+		return Integral(Simplify(v.Body), v.X, Simplify(v.Lower), Simplify(v.Upper))
+	case *conversion:
+		// the unit is kept as written: it is the one of the result
+		return Convert(Simplify(v.Value), v.Unit)
+	}
+	return e
+}
+
+func simplifyAll(exps []Expression) []Expression {
+	res := make([]Expression, len(exps))
+	for i, e := range exps {
+		res[i] = Simplify(e)
+	}
+	return res
+}
+
+// fold evaluates e if all its arguments are numbers
+func fold(e Expression, args []Expression) Expression {
+	for _, a := range args {
+		if _, ok := constant(a); !ok {
+			return e
+		}
+	}
+	v, err := e.Eval(nil)
+	if err != nil {
+		// the error will be thrown when the Expression is evaluated
+		return e
+	}
+	return ConstComplex(v)
+}
+
+func simplifyCondition(c Condition) Condition {
+	switch v := c.(type) {
+	case *comparison:
+		return &comparison{Simplify(v.Left), Simplify(v.Right), v.op}
+	case *logic:
+		return &logic{simplifyCondition(v.Left), simplifyCondition(v.Right), v.isAnd}
+	case *not:
+		return Not(simplifyCondition(v.Left))
+	}
+	return c
+}
+
+// simplifyPiecewise removes the branches whose condition is always false, and the ones following a condition always
+// true
+func simplifyPiecewise(p *piecewise) Expression {
+	var conds []Condition
+	var values []Expression
+	otherwise := Simplify(p.Otherwise)
+	for i, c := range p.Conditions {
+		c = simplifyCondition(c)
+		if len(Variables(c)) == 0 {
+			b, err := c.Test(nil)
+			if err == nil && !b {
+				continue
+			}
+			if err == nil {
+				otherwise = Simplify(p.Values[i])
+				break
+			}
+		}
+		conds = append(conds, c)
+		values = append(values, Simplify(p.Values[i]))
+	}
+	if len(conds) == 0 {
+		return otherwise
+	}
+	return Piecewise(conds, values, otherwise)
+}
+
+// terms returns the monomials whose sum is e
+func terms(e Expression) []*monomial {
+	switch v := e.(type) {
+	case *addition:
+		return append(terms(v.Left), terms(v.Right)...)
+	case *negation:
+		ts := terms(v.Left)
+		res := make([]*monomial, len(ts))
+		for i, t := range ts {
+			res[i] = &monomial{t.coef.Neg(), t.factors}
+		}
+		return res
+	case *multiplication:
+		return multiplyTerms(terms(v.Left), terms(v.Right))
+	case *division:
+		return multiplyTerms(terms(v.Left), inverseTerms(terms(v.Right)))
+	case *pow:
+		return powTerms(terms(v.Left), Simplify(v.Right))
+	case *predefinedFunction:
+		if v.ID == "sqrt" && v.fn == DefaultRegistry.functions["sqrt"] {
+			return powTerms(terms(v.args[0]), Const(math.NewFraction(1, 2)))
+		}
+	case *predefinedVariable:
+		// the constants of GoMath, like pi, are numbers: 2pi + pi is 3pi
+		if v.saved.Val.LaTeX() == v.saved.LaTeX {
+			return []*monomial{{v.saved.Val, nil}}
+		}
+	}
+	s := Simplify(e)
+	if c, ok := constant(s); ok {
+		if c.IsNull() {
+			return nil
+		}
+		return []*monomial{{c, nil}}
+	}
+	return []*monomial{baseMonomial(s, math.OneFraction)}
+}
+
+// baseMonomial returns the monomial base^exp
+func baseMonomial(base Expression, exp *math.Fraction) *monomial {
+	return &monomial{math.OneComplex, []*monomialFactor{{base, String(base), exp}}}
+}
+
+// collect adds the like terms and sorts them: the highest degrees first and the numbers last
+func collect(ts []*monomial) []*monomial {
+	var res []*monomial
+	keys := map[string]*monomial{}
+	for _, t := range ts {
+		k := t.key()
+		if m, ok := keys[k]; ok {
+			m.coef = m.coef.Add(t.coef)
+			continue
+		}
+		m := &monomial{t.coef, t.factors}
+		keys[k] = m
+		res = append(res, m)
+	}
+	res = slices.DeleteFunc(res, func(m *monomial) bool {
+		return m.coef.IsNull()
+	})
+	slices.SortStableFunc(res, func(a, b *monomial) int {
+		if c := b.degree().Cmp(a.degree().Rat); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key(), b.key())
+	})
+	return res
+}
+
+// asMonomial returns the sum of the monomials as a single monomial
+func asMonomial(ts []*monomial) *monomial {
+	ts = collect(ts)
+	switch len(ts) {
+	case 0:
+		return &monomial{math.NullComplex, nil}
+	case 1:
+		return ts[0]
+	}
+	return baseMonomial(rebuild(ts), math.OneFraction)
+}
+
+func multiplyTerms(a, b []*monomial) []*monomial {
+	ma, mb := asMonomial(a), asMonomial(b)
+	if ma.coef.IsNull() || mb.coef.IsNull() {
+		return nil
+	}
+	return []*monomial{ma.mul(mb)}
+}
+
+func inverseTerms(ts []*monomial) []*monomial {
+	m := asMonomial(ts)
+	inv, err := m.coef.Inv()
+	if err != nil {
+		// the division by 0 is kept, so it throws an error when it is evaluated
+		return []*monomial{baseMonomial(zero(), math.IntToFraction(-1))}
+	}
+	res := &monomial{inv, make([]*monomialFactor, len(m.factors))}
+	for i, f := range m.factors {
+		res.factors[i] = &monomialFactor{f.base, f.key, f.exp.Neg()}
+	}
+	return []*monomial{res}
+}
+
+func powTerms(ts []*monomial, exp Expression) []*monomial {
+	m := asMonomial(ts)
+	c, ok := constant(exp)
+	if !ok {
+		return []*monomial{baseMonomial(Pow(rebuild(ts), exp), math.OneFraction)}
+	}
+	if c.IsNull() {
+		return []*monomial{{math.OneComplex, nil}}
+	}
+	if len(m.factors) == 0 {
+		v, err := m.coef.Exp(c)
+		if err != nil {
+			return []*monomial{baseMonomial(Pow(rebuild(ts), exp), math.OneFraction)}
+		}
+		return []*monomial{{v, nil}}
+	}
+	n, ok := c.Fraction()
+	if !ok {
+		return []*monomial{baseMonomial(Pow(rebuild(ts), exp), math.OneFraction)}
+	}
+	if n.IsInt() {
+		// (2x^2)^3 is 8x^6
+		coef, err := m.coef.Exp(c)
+		if err != nil {
+			return []*monomial{baseMonomial(rebuild(ts), n)}
+		}
+		res := &monomial{coef, make([]*monomialFactor, len(m.factors))}
+		for i, f := range m.factors {
+			res.factors[i] = &monomialFactor{f.base, f.key, f.exp.Mul(n)}
+		}
+		return []*monomial{res}
+	}
+	if m.coef.Is(math.OneComplex) && len(m.factors) == 1 && m.factors[0].exp.Is(math.OneFraction) {
+		return []*monomial{baseMonomial(m.factors[0].base, n)}
+	}
+	// (x^2)^(1/2) is |x| and not x
+	return []*monomial{baseMonomial(rebuild(ts), n)}
+}
+
+func (m *monomial) mul(a *monomial) *monomial {
+	res := &monomial{m.coef.Mul(a.coef), nil}
+	factors := append(slices.Clone(m.factors), a.factors...)
+	for _, f := range factors {
+		i := slices.IndexFunc(res.factors, func(r *monomialFactor) bool {
+			return r.key == f.key
+		})
+		if i < 0 {
+			res.factors = append(res.factors, f)
+			continue
+		}
+		res.factors[i] = &monomialFactor{f.base, f.key, res.factors[i].exp.Add(f.exp)}
+	}
+	res.factors = slices.DeleteFunc(res.factors, func(f *monomialFactor) bool {
+		return f.exp.Sign() == 0
+	})
+	// the variables are written first, like x*sin(x)
+	slices.SortStableFunc(res.factors, func(a, b *monomialFactor) int {
+		if c := isVariable(b.base) - isVariable(a.base); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	return res
+}
+
+func isVariable(e Expression) int {
+	switch e.(type) {
+	case *literalExpression, *predefinedVariable:
+		return 1
+	}
+	return 0
+}
+
+// key identifies the like terms: 2x^2y and -x^2y have the same key
+func (m *monomial) key() string {
+	var sb strings.Builder
+	for _, f := range m.factors {
+		sb.WriteString(f.key + "^" + f.exp.String() + ";")
+	}
+	return sb.String()
+}
+
+// degree returns the sum of the exponents of the monomial
+func (m *monomial) degree() *math.Fraction {
+	d := math.NullFraction
+	for _, f := range m.factors {
+		d = d.Add(f.exp)
+	}
+	return d
+}
+
+// rebuild returns the sum of the monomials
+func rebuild(ts []*monomial) Expression {
+	ts = collect(ts)
+	switch {
+	case len(ts) == 0:
+		return zero()
+	case len(ts) == 1 && len(ts[0].factors) == 0:
+		return ConstComplex(ts[0].coef)
+	}
+	var res Expression
+	for _, t := range ts {
+		coef := t.coef
+		negative := isNegative(coef)
+		if negative {
+			coef = coef.Neg()
+		}
+		e := (&monomial{coef, t.factors}).expression()
+		switch {
+		case res == nil && negative:
+			res = Neg(e)
+		case res == nil:
+			res = e
+		case negative:
+			res = Sub(res, e)
+		default:
+			res = Add(res, e)
+		}
+	}
+	return res
+}
+
+// expression returns the monomial as an Expression, the factors with a negative exponent being in the denominator
+func (m *monomial) expression() Expression {
+	if len(m.factors) == 0 {
+		return ConstComplex(m.coef)
+	}
+	var num, den []Expression
+	// inverse is the coefficient written after the factors of the denominator
+	var inverse Expression
+	if f, ok := m.coef.Fraction(); ok {
+		// 3x/2 and not 3/2 x
+		num = append(num, Const(&math.Fraction{Rat: new(big.Rat).SetInt(f.Num())}))
+		if !f.IsInt() {
+			den = append(den, Const(&math.Fraction{Rat: new(big.Rat).SetInt(f.Denom())}))
+		}
+	} else if inv, err := m.coef.Inv(); err == nil && len(inv.String()) < len(m.coef.String()) {
+		// x/ln(2) and not 1/ln(2) x
+		inverse = ConstComplex(inv)
+	} else {
+		num = append(num, ConstComplex(m.coef))
+	}
+	for _, f := range m.factors {
+		if f.exp.Sign() > 0 {
+			num = append(num, factorExpression(f.base, f.exp))
+		} else {
+			den = append(den, factorExpression(f.base, f.exp.Neg()))
+		}
+	}
+	if inverse != nil {
+		den = append(den, inverse)
+	}
+	if len(num) > 1 && isConstant(num[0], math.OneComplex) {
+		num = num[1:]
+	}
+	res := product(num)
+	if len(den) == 0 {
+		return res
+	}
+	return Div(res, product(den))
+}
+
+func factorExpression(base Expression, exp *math.Fraction) Expression {
+	switch {
+	case exp.Is(math.OneFraction):
+		return base
+	case exp.Is(math.NewFraction(1, 2)):
+		return call("sqrt", base)
+	}
+	return Pow(base, Const(exp))
+}
+
+func product(exps []Expression) Expression {
+	if len(exps) == 0 {
+		return one()
+	}
+	res := exps[0]
+	for _, e := range exps[1:] {
+		res = Mul(res, e)
+	}
+	return res
+}
+
+// isNegative returns true if the first non-null part of z is negative
+func isNegative(z *math.Complex) bool {
+	if !z.Re().IsNull() {
+		return z.Re().Sign() < 0
+	}
+	return z.Im().Sign() < 0
+}
@@ -0,0 +1,124 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// String returns the plain text representation of the Expression, like 4x^2 + sin(x)/2.
+// The representation can be parsed again by GoMath.
+func String(e Expression) string {
+	s, _ := plain(e)
+	return s
+}
+
+// plain returns the plain text representation of the Expression with its priority
+func plain(e Expression) (string, priority) {
+	switch v := e.(type) {
+	case *constExp:
+		s := v.Value.String()
+		switch {
+		case strings.Contains(s, " "):
+			return s, termPriority
+		case strings.HasPrefix(s, "-"):
+			return s, unaryPriority
+		case strings.Contains(s, "/"):
+			return s, factorPriority
+		}
+		return s, literalPriority
+	case *literalExpression:
+		return string(*v), literalPriority
+	case *predefinedVariable:
+		return v.ID, literalPriority
+	case *unit:
+		return v.ID, literalPriority
+	case *currency:
+		return v.Code, literalPriority
+	case *predefinedFunction:
+		return plainCall(v.ID, v.args), literalPriority
+	case *userCall:
+		return plainCall(v.ID, v.args), literalPriority
+	case *addition:
+		l := plainParenthesis(v.Left, termPriority)
+		if n, ok := v.Right.(*negation); ok && !n.IsSingle() {
+			return fmt.Sprintf("%s - %s", l, plainParenthesis(n.Left, factorPriority)), termPriority
+		}
+		return fmt.Sprintf("%s + %s", l, plainParenthesis(v.Right, termPriority)), termPriority
+	case *negation:
+		// -(a*b) is (-a)*b, so the products do not need parenthesis
+		return "-" + plainParenthesis(v.Left, factorPriority), unaryPriority
+	case *multiplication:
+		l, lp := plain(v.Left)
+		if lp < factorPriority {
+			l = "(" + l + ")"
+		}
+		r, p := plain(v.Right)
+		if p < factorPriority || strings.HasPrefix(r, "-") {
+			r = "(" + r + ")"
+		}
+		// 2x and 2sqrt(2) are written without the operator, but not 1/3*x which would be read as 1/(3x)
+		if lp > factorPriority && unicode.IsDigit(rune(l[len(l)-1])) && (unicode.IsLetter(rune(r[0])) || r[0] == '(') {
+			return l + r, factorPriority
+		}
+		return l + "*" + r, factorPriority
+	case *division:
+		return plainParenthesis(v.Left, factorPriority) + "/" + plainParenthesis(v.Right, expPriority), factorPriority
+	case *pow:
+		return plainParenthesis(v.Left, literalPriority) + "^" + plainParenthesis(v.Right, literalPriority), expPriority
+	case *modulo:
+		return plainCall("mod", []Expression{v.Left, v.Right}), literalPriority
+	case *factorial:
+		return plainParenthesis(v.Left, literalPriority) + "!", unaryPriority
+	case *degree:
+		return plainParenthesis(v.Left, literalPriority) + "°", expPriority
+	case *Equation:
+		return fmt.Sprintf("%s = %s", String(v.Left), String(v.Right)), equationPriority
+	case *comparison:
+		l := plainParenthesis(v.Left, termPriority)
+		r := plainParenthesis(v.Right, termPriority)
+		return fmt.Sprintf("%s %s %s", l, v.op, r), comparisonPriority
+	case *logic:
+		if v.isAnd {
+			l := plainParenthesis(v.Left, andPriority)
+			return fmt.Sprintf("%s and %s", l, plainParenthesis(v.Right, andPriority)), andPriority
+		}
+		return fmt.Sprintf("%s or %s", String(v.Left), String(v.Right)), orPriority
+	case *not:
+		return "not " + plainParenthesis(v.Left, notPriority), notPriority
+	case *piecewise:
+		args := make([]Expression, 0, 2*len(v.Conditions)+1)
+		for i, c := range v.Conditions {
+			args = append(args, c, v.Values[i])
+		}
+		args = append(args, v.Otherwise)
+		if len(v.Conditions) == 1 {
+			return plainCall("if", args), literalPriority
+		}
+		return plainCall("piecewise", args), literalPriority
+	case *integral:
+		x := literalExpression(v.X)
+		return plainCall("integrate", []Expression{v.Body, &x, v.Lower, v.Upper}), literalPriority
+	case *conversion:
+		return plainCall("convert", []Expression{v.Value, v.Unit}), literalPriority
+	}
+	return fmt.Sprintf("%v", e), literalPriority
+}
+
+// plainParenthesis returns the plain text representation of the Expression, between parenthesis if its priority is
+// lower than current
+func plainParenthesis(e Expression, current priority) string {
+	s, p := plain(e)
+	if p < current {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func plainCall(id string, args []Expression) string {
+	vals := make([]string, len(args))
+	for i, a := range args {
+		vals[i] = String(a)
+	}
+	return fmt.Sprintf("%s(%s)", id, strings.Join(vals, ", "))
+}
@@ -0,0 +1,114 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/math"
+	"strings"
+)
+
+var (
+	// ErrRecursionLimit is thrown when the calls of user-defined functions are nested more than MaxCallDepth times
+	ErrRecursionLimit = errors.New("recursion limit reached")
+
+	// MaxCallDepth is the maximum number of nested calls of user-defined functions
+	MaxCallDepth = 1000
+)
+
+// userFunction is a function defined by an Expression, like f(x, y) = x^y
+type userFunction struct {
+	Params []string
+	Body   Expression
+	// Scope is the Scope of the definition, in which the other variables of Body are evaluated (can be nil)
+	Scope *Scope
+}
+
+type userCall struct {
+	ID   string
+	fn   *userFunction
+	args []Expression
+}
+
+// SetUserFunction registers the function name taking the parameters params.
+// Its body is returned by parse, called with the Registry already containing name, so the function can call itself.
+// The variables of the body which are not parameters are evaluated in scope, the Scope of the definition (can be nil),
+// and not in the Scope of the caller.
+// Returns ErrInvalidName if name is not only composed of letters or if it is already used by a constant.
+func (r *Registry) SetUserFunction(
+	name string,
+	params []string,
+	scope *Scope,
+	parse func(*Registry) (Expression, error),
+) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+	if _, ok := r.variables[name]; ok {
+		return errors.Join(ErrInvalidName, fmt.Errorf("%s is already a constant", name))
+	}
+	oldUser, hadUser := r.users[name]
+	oldFn, hadFn := r.functions[name]
+	fn := &userFunction{Params: params, Scope: scope}
+	delete(r.functions, name)
+	r.users[name] = fn
+	body, err := parse(r)
+	if err != nil {
+		// restores the previous definition
+		delete(r.users, name)
+		if hadUser {
+			r.users[name] = oldUser
+		}
+		if hadFn {
+			r.functions[name] = oldFn
+		}
+		return err
+	}
+	fn.Body = body
+	return nil
+}
+
+// callScope returns the Scope in which the body is evaluated, without the parameters.
+// Returns ErrRecursionLimit if there are too many nested calls.
+func (c *userCall) callScope(s *Scope) (*Scope, error) {
+	// the body is evaluated in the Scope of the definition: only the depth, the angle unit and the money mode come from
+	// the caller
+	call := NewScope(c.fn.Scope)
+	call.angle = s.AngleUnit()
+	call.depth = 1
+	if s != nil {
+		call.depth += s.depth
+		call.rates = s.rates
+		call.money = s.money
+	}
+	if call.depth > MaxCallDepth {
+		return nil, errors.Join(ErrRecursionLimit, fmt.Errorf("%s: more than %d nested calls", c.ID, MaxCallDepth))
+	}
+	return call, nil
+}
+
+func (c *userCall) Eval(s *Scope) (*math.Complex, error) {
+	call, err := c.callScope(s)
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range c.args {
+		v, err := a.Eval(s)
+		if err != nil {
+			return nil, err
+		}
+		call.SetComplex(c.fn.Params[i], v)
+	}
+	return c.fn.Body.Eval(call)
+}
+
+func (c *userCall) RenderLatex() (string, priority, error) {
+	vals := make([]string, len(c.args))
+	for i, a := range c.args {
+		val, _, err := a.RenderLatex()
+		if err != nil {
+			return "", literalPriority, err
+		}
+		vals[i] = val
+	}
+	return fmt.Sprintf(`%s\left(%s\right)`, c.ID, strings.Join(vals, ", ")), literalPriority, nil
+}
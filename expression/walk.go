@@ -0,0 +1,83 @@
+package expression
+
+import "slices"
+
+// children returns the sub-Expression of the given Expression
+func children(e Expression) []Expression {
+	switch v := e.(type) {
+	case *addition:
+		return []Expression{v.Left, v.Right}
+	case *negation:
+		return []Expression{v.Left}
+	case *multiplication:
+		return []Expression{v.Left, v.Right}
+	case *division:
+		return []Expression{v.Left, v.Right}
+	case *pow:
+		return []Expression{v.Left, v.Right}
+	case *modulo:
+		return []Expression{v.Left, v.Right}
+	case *factorial:
+		return []Expression{v.Left}
+	case *degree:
+		return []Expression{v.Left}
+	case *predefinedFunction:
+		return v.args
+	case *userCall:
+		return v.args
+	case *Equation:
+		return []Expression{v.Left, v.Right}
+	case *comparison:
+		return []Expression{v.Left, v.Right}
+	case *logic:
+		return []Expression{v.Left, v.Right}
+	case *not:
+		return []Expression{v.Left}
+	case *piecewise:
+		res := make([]Expression, 0, 2*len(v.Conditions)+1)
+		for i, c := range v.Conditions {
+			res = append(res, c, v.Values[i])
+		}
+		return append(res, v.Otherwise)
+	case *integral:
+		return []Expression{v.Body, v.Lower, v.Upper}
+	case *conversion:
+		return []Expression{v.Value, v.Unit}
+	}
+	return nil
+}
+
+// Variables returns the name of the user-defined variables used by the Expression, in order of appearance
+func Variables(e Expression) []string {
+	var vars []string
+	var walk func(Expression)
+	walk = func(e Expression) {
+		if l, ok := e.(*literalExpression); ok {
+			if !slices.Contains(vars, string(*l)) {
+				vars = append(vars, string(*l))
+			}
+			return
+		}
+		if i, ok := e.(*integral); ok {
+			// the variable of integration is bound in the body
+			for _, v := range Variables(i.Body) {
+				if v != i.X && !slices.Contains(vars, v) {
+					vars = append(vars, v)
+				}
+			}
+			walk(i.Lower)
+			walk(i.Upper)
+			return
+		}
+		for _, c := range children(e) {
+			walk(c)
+		}
+	}
+	walk(e)
+	return vars
+}
+
+// DependsOn returns true if the Expression uses the variable x
+func DependsOn(e Expression, x string) bool {
+	return slices.Contains(Variables(e), x)
+}
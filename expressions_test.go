@@ -1,8 +1,11 @@
 package gomath
 
 import (
+	"errors"
 	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
 	"github.com/nyttikord/gomath/lexer"
+	"github.com/nyttikord/gomath/math"
 	"testing"
 )
 
@@ -183,3 +186,254 @@ func genericTestRenderLatex(t *testing.T, exp string, excepted string) {
 	//	t.Log(tree)
 	//}
 }
+
+func TestEvalExactDecimal(t *testing.T) {
+	genericTest(t, "12345678901234567890+1", "12345678901234567891")
+	genericTest(t, "1.000000000000000000001-1", "1/1000000000000000000000")
+	genericTest(t, "6.02e23/2", "301000000000000000000000")
+	// 2e-1 is 0.2, not 2e - 1
+	genericTest(t, "2e-1", "1/5")
+	genericTest(t, "2e+1", "20")
+	genericTest(t, "2e - 1", "-1 + 2e")
+	genericTest(t, "0.1+0.2", "3/10")
+}
+
+func TestEvalRationalExponent(t *testing.T) {
+	genericTest(t, "8^(2/3)", "4")
+	genericTest(t, "4^(1/2)", "2")
+	genericTest(t, "2^-3", "1/8")
+	genericTest(t, "(-8)^(1/3)", "-2")
+	genericTest(t, "2^(3/2)", "2sqrt(2)")
+	genericTest(t, "3^(1/3)", "root(3, 3)")
+
+	lexr, err := lexer.Lex("(-8)^(1/2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ast.Parse(lexr, ast.TypeCalculation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tree.Body.Eval(&ast.Options{})
+	if !errors.Is(err, math.ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+}
+
+func TestEvalComplex(t *testing.T) {
+	genericTest(t, "sqrt(-1)", "i")
+	genericTest(t, "i^2", "-1")
+	genericTest(t, "(1+2i)(3-i)", "5 + 5i")
+	genericTest(t, "1/(1+i)", "1/2 - (1/2)i")
+	genericTest(t, "ln(-2)", "ln(2) + pi*i")
+	genericTest(t, "exp(i*pi)", "-1")
+	genericTest(t, "sqrt(3+4i)", "2 + i")
+	genericTest(t, "(1+i)^10", "32i")
+	genericTestRenderLatex(t, "3+4i", `3 + 4i`)
+	genericTestRenderLatex(t, "pi*i", `\pi i`)
+}
+
+func TestEvalModulo(t *testing.T) {
+	genericTest(t, "7%3", "1")
+	genericTest(t, "-7%3", "2")
+	genericTest(t, "7%-3", "-2")
+	genericTest(t, "5.5%2", "3/2")
+	genericTest(t, "2+7%3*2", "4")
+	genericTest(t, "mod(-7, 3)", "2")
+	genericTestRenderLatex(t, "7%3", `7 \bmod 3`)
+	genericTestRenderLatex(t, "mod(7, 3)", `7 \bmod 3`)
+	genericTestRenderLatex(t, "7%(1+2)", `7 \bmod \left(1 + 2\right)`)
+}
+
+func TestEvalEquation(t *testing.T) {
+	genericTestRenderLatex(t, "x^2 = 2x+1", `x^2 = 2 \times x + 1`)
+
+	lexr, err := lexer.Lex("x = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ast.Parse(lexr, ast.TypeCalculation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tree.Body.Eval(&ast.Options{})
+	if !errors.Is(err, expression.ErrNotEvaluable) {
+		t.Errorf("expected not evaluable error, not %v", err)
+	}
+}
+
+func TestEvalComparison(t *testing.T) {
+	genericTest(t, "2 < 3", "true")
+	genericTest(t, "2 >= 3", "false")
+	genericTest(t, "1/2 == 0.5", "true")
+	genericTest(t, "i != 1", "true")
+	genericTest(t, "sin(1)^2 + cos(1)^2 == 1", "true")
+	genericTest(t, "ln(2) + ln(3) == ln(6)", "true")
+	genericTest(t, "ln(2) + ln(3) != ln(6)", "false")
+	genericTest(t, "sqrt(2) == 1.4142135623730951", "false")
+	genericTest(t, "1 < 2 < 3", "true")
+	genericTest(t, "1 < 3 < 2", "false")
+	genericTest(t, "2 < 3 and 3 < 2", "false")
+	genericTest(t, "2 < 3 or 3 < 2", "true")
+	genericTest(t, "not 2 < 3", "false")
+	genericTest(t, "(2 > 1)*5", "5")
+
+	genericTestRenderLatex(t, "x <= 3", `x \leq 3`)
+	genericTestRenderLatex(t, "x != 2 and x >= 1", `x \neq 2 \land x \geq 1`)
+	genericTestRenderLatex(t, "(x < 1 or x > 2) and y == 0", `\left(x < 1 \lor x > 2\right) \land y = 0`)
+	genericTestRenderLatex(t, "not x < 2", `\lnot \left(x < 2\right)`)
+
+	lexr, err := lexer.Lex("i < 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ast.Parse(lexr, ast.TypeCalculation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tree.Body.Eval(&ast.Options{})
+	if !errors.Is(err, expression.ErrNumberNotInSpace) {
+		t.Errorf("expected number not in space error, not %v", err)
+	}
+}
+
+func TestEvalConditional(t *testing.T) {
+	genericTest(t, "if(2 > 3, 1, 2)", "2")
+	genericTest(t, "if(3 > 2, 1, 2)", "1")
+	// only the chosen branch is evaluated
+	genericTest(t, "if(1 > 0, 5, 1/0)", "5")
+	genericTest(t, "piecewise(12 < 10, 5, 12 < 20, 4, 3)*12", "48")
+	genericTest(t, "piecewise(25 < 10, 5, 25 < 20, 4, 3)*25", "75")
+
+	genericTestRenderLatex(
+		t,
+		"piecewise(x < 10, 5, x < 20, 4, 3)",
+		`\begin{cases} 5 & \text{if } x < 10 \\ 4 & \text{if } x < 20 \\ 3 & \text{otherwise} \end{cases}`,
+	)
+	genericTestRenderLatex(t, "if(x >= 0, x, -x)", `\begin{cases} x & \text{if } x \geq 0 \\ -x & \text{otherwise} \end{cases}`)
+
+	for _, exp := range []string{"if(2, 1, 2)", "if(1 < 2, 1)", "piecewise(1 < 2, 3)"} {
+		lexr, err := lexer.Lex(exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ast.Parse(lexr, ast.TypeCalculation)
+		if !errors.Is(err, ast.ErrInvalidExpression) {
+			t.Errorf("%s: expected invalid expression error, not %v", exp, err)
+		}
+	}
+}
+
+func TestEvalTrigonometry(t *testing.T) {
+	genericTest(t, "sin(pi/6)", "1/2")
+	genericTest(t, "cos(pi/4)", "sqrt(2)/2")
+	genericTest(t, "tan(-pi/3)", "-sqrt(3)")
+	genericTest(t, "sin(5pi/12)", "sqrt(2)/4 + sqrt(6)/4")
+	genericTest(t, "sec(2pi/3)", "-2")
+	genericTest(t, "cot(pi/4)", "1")
+	genericTest(t, "asin(-1/2)", "-pi/6")
+	genericTest(t, "acos(sqrt(2)/2)", "pi/4")
+	genericTest(t, "atan(sqrt(3))", "pi/3")
+	genericTest(t, "sinh(ln(2))", "3/4")
+	genericTest(t, "cosh(0)", "1")
+	genericTest(t, "atanh(tanh(1/2))", "1/2")
+
+	genericTestRenderLatex(t, "asin(x)", `\arcsin\left(x\right)`)
+	genericTestRenderLatex(t, "cosh(x)", `\cosh\left(x\right)`)
+	genericTestRenderLatex(t, "atanh(x)", `\operatorname{artanh}\left(x\right)`)
+
+	for _, exp := range []string{"asin(2)", "acos(-3/2)", "acosh(0)", "atanh(-1)", "cot(pi)", "sec(pi/2)"} {
+		lexr, err := lexer.Lex(exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree, err := ast.Parse(lexr, ast.TypeCalculation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tree.Body.Eval(&ast.Options{})
+		if !errors.Is(err, expression.ErrNumberNotInSpace) {
+			t.Errorf("%s: expected number not in space error, not %v", exp, err)
+		}
+	}
+}
+
+func TestEvalAngleUnit(t *testing.T) {
+	genericTest := func(exp string, unit expression.AngleUnit, excepted string) {
+		r, err := Parse(exp, &ast.Options{Angle: unit})
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if r.String() != excepted {
+			t.Errorf("%s in %s: got %s; want %s", exp, unit, r, excepted)
+		}
+	}
+	genericTest("sin(30)", expression.Degree, "1/2")
+	genericTest("cos(100)", expression.Gradian, "0")
+	genericTest("tan(pi/4)", expression.Radian, "1")
+	genericTest("asin(1/2)", expression.Degree, "30")
+	genericTest("atan2(1, -1)", expression.Gradian, "150")
+	genericTest("acos(-1)", expression.Radian, "pi")
+	for _, unit := range []expression.AngleUnit{expression.Radian, expression.Degree, expression.Gradian} {
+		genericTest("sin(30°)", unit, "1/2")
+		genericTest("cos(60 deg)", unit, "1/2")
+		genericTest("asin(1)/90°", unit, "1")
+	}
+	genericTest("180°", expression.Radian, "pi")
+	genericTest("90deg", expression.Gradian, "100")
+
+	scope := expression.NewScope(nil)
+	scope.SetFraction("a", math.IntToFraction(30))
+	r, err := Parse("sin(a°)", &ast.Options{Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "1/2" {
+		t.Errorf("sin(a°): got %s; want 1/2", r)
+	}
+
+	genericTestRenderLatex(t, "sin(30°)", `\sin\left(30^\circ\right)`)
+	genericTestRenderLatex(t, "x°", `x^\circ`)
+	genericTestRenderLatex(t, "sin(x°)", `\sin\left(x^\circ\right)`)
+	genericTestRenderLatex(t, "(x + 1)deg", `\left(x + 1\right)^\circ`)
+
+	for _, s := range []string{"rad", "deg", "grad"} {
+		u, err := expression.ParseAngleUnit(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() != s {
+			t.Errorf("got %s; want %s", u, s)
+		}
+	}
+	if _, err := expression.ParseAngleUnit("turn"); !errors.Is(err, expression.ErrUnknownAngleUnit) {
+		t.Errorf("excepted unknown angle unit error, got %v", err)
+	}
+}
+
+func TestEvalMultiArgumentFunction(t *testing.T) {
+	genericTest(t, "max(1, 3/2, -2)", "3/2")
+	genericTest(t, "min(pi, 3)", "3")
+	genericTest(t, "gcd(12, 18, 8)", "2")
+	genericTest(t, "lcm(4, 6)", "12")
+	genericTest(t, "log(2, 8)", "3")
+	genericTest(t, "log(100)", "2")
+	genericTest(t, "root(3, -27)", "-3")
+	genericTest(t, "atan2(1, -1)", "3pi/4")
+
+	genericTestRenderLatex(t, "log(2, x)", `\log_{2}\left(x\right)`)
+	genericTestRenderLatex(t, "root(3, x)", `\sqrt[3]{x}`)
+	genericTestRenderLatex(t, "max(a, b, c)", `\max\left(a, b, c\right)`)
+	genericTestRenderLatex(t, "lcm(a, b)", `\operatorname{lcm}\left(a, b\right)`)
+
+	for _, exp := range []string{"max(1)", "atan2(1, 2, 3)", "sqrt(1, 2)", "log(1, 2, 3)"} {
+		lexr, err := lexer.Lex(exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ast.Parse(lexr, ast.TypeCalculation)
+		if !errors.Is(err, expression.ErrInvalidArity) {
+			t.Errorf("%s: expected invalid arity error, not %v", exp, err)
+		}
+	}
+}
@@ -3,6 +3,11 @@ package gomath
 import (
 	"errors"
 	"fmt"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/lexer"
+	"github.com/nyttikord/gomath/math"
+	"slices"
 	"strings"
 )
 
@@ -11,7 +16,19 @@ var (
 	ErrInvalidFunctionCall = errors.New("invalid function call")
 )
 
-type Function func(map[string]string) (Result, error)
+// Function is a compiled function: its expression is parsed once and then evaluated for each call by binding the
+// arguments as variables.
+type Function struct {
+	params []string
+	body   string
+	tree   *ast.Ast
+	// angle is the unit of the angles used when the Function is called
+	angle expression.AngleUnit
+	// scope contains the variables used by the expression which are not parameters (can be nil)
+	scope *expression.Scope
+	// units and money are true if the expression is parsed with the units of measurement and the currencies
+	units, money bool
+}
 
 // NewFunction creates a new Function by parsing the given string. It must follow this scheme:
 //
@@ -21,30 +38,174 @@ type Function func(map[string]string) (Result, error)
 //
 //	gomath.NewFunction("x, y -> x^y")
 //
-// It returns the Function and the number of arguments.
-func NewFunction(s string) (Function, int, error) {
+// The optional Options give the expression.Registry used to parse the expression, the unit of the angles used by the
+// calls and the Scope in which the variables of the expression which are not parameters are evaluated.
+func NewFunction(s string, opts ...*ast.Options) (*Function, error) {
 	splits := strings.Split(s, "->")
 	if len(splits) != 2 {
-		return nil, 0, errors.Join(ErrInvalidFunction, errors.New("a function is defined by 'args -> expression'"))
+		return nil, errors.Join(ErrInvalidFunction, errors.New("a function is defined by 'args -> expression'"))
 	}
 	before := splits[0]
-	expression := strings.TrimSpace(splits[1])
 	var params []string
 	for _, p := range strings.Split(before, ",") {
-		params = append(params, strings.TrimSpace(p))
+		p = strings.TrimSpace(p)
+		if err := checkParameter(p, params); err != nil {
+			return nil, err
+		}
+		params = append(params, p)
+	}
+	var opt *ast.Options
+	var scope *expression.Scope
+	angle := expression.Radian
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+		angle = opt.Angle
+		scope = opt.Scope
+	}
+	body := strings.TrimSpace(splits[1])
+	tree, err := parseAst(body, ast.TypeCalculation, opt)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidFunction, err)
+	}
+	f := &Function{params: params, body: body, tree: tree, angle: angle, scope: scope}
+	if opt != nil {
+		reg := opt.ParseRegistry()
+		f.units, f.money = reg.HasUnits(), reg.HasMoney()
+	}
+	return f, nil
+}
+
+// Register adds the Function to reg with the given name, so the expressions parsed with reg can call it, like
+// f(2, 3) + 1.
+// The expression of the Function is parsed again with reg, the units of measurement and the currencies being enabled
+// if they were enabled by the Options given to NewFunction: it can call itself, like
+//
+//	n -> if(n <= 1, 1, n*fact(n - 1))
+//
+// The number of nested calls is limited by expression.MaxCallDepth.
+// The variables of the expression which are not parameters are evaluated in the Scope given to NewFunction, not in the
+// one of the caller.
+func (f *Function) Register(name string, reg *expression.Registry) error {
+	if ast.IsKeyword(name) {
+		return errors.Join(expression.ErrInvalidName, fmt.Errorf("%s is a keyword", name))
+	}
+	err := reg.SetUserFunction(name, f.Params(), f.scope, func(r *expression.Registry) (expression.Expression, error) {
+		opt := &ast.Options{Registry: r, Units: f.units}
+		if f.money {
+			opt.Money = &ast.MoneyOptions{}
+		}
+		tree, err := parseAst(f.body, ast.TypeCalculation, opt)
+		if err != nil {
+			return nil, err
+		}
+		return tree.Expression(), nil
+	})
+	if err != nil {
+		return errors.Join(ErrInvalidFunction, err)
+	}
+	return nil
+}
+
+// Params returns the name of each parameter, in order
+func (f *Function) Params() []string {
+	return slices.Clone(f.params)
+}
+
+// Arity returns the number of parameters
+func (f *Function) Arity() int {
+	return len(f.params)
+}
+
+// Call the Function with the given arguments.
+// The number of arguments must be equal to Arity and they are bound in the order given by Params.
+// Returns ErrInvalidFunctionCall if an argument is nil.
+func (f *Function) Call(args ...*math.Fraction) (Result, error) {
+	values := make([]*math.Complex, len(args))
+	for i, a := range args {
+		if a == nil || a.Rat == nil {
+			return nil, errors.Join(ErrInvalidFunctionCall, fmt.Errorf("argument %d is nil", i+1))
+		}
+		values[i] = math.FractionToComplex(a)
 	}
-	return func(args map[string]string) (Result, error) {
-		if len(params) != len(args) {
-			return nil, errors.Join(ErrInvalidFunctionCall, errors.New("not all parameters have been defined"))
+	return f.call(values)
+}
+
+// call the Function with the given arguments
+func (f *Function) call(args []*math.Complex) (Result, error) {
+	if len(args) != len(f.params) {
+		return nil, errors.Join(
+			ErrInvalidFunctionCall,
+			fmt.Errorf("%d arguments excepted, got %d", len(f.params), len(args)),
+		)
+	}
+	scope := expression.NewScope(f.scope)
+	for i, p := range f.params {
+		scope.SetComplex(p, args[i])
+	}
+	// copying the tree prevents Result.LaTeX from modifying the shared one
+	cp := *f.tree
+	r, err := cp.Body.Eval(&ast.Options{Scope: scope, Angle: f.angle})
+	if err != nil {
+		return nil, err
+	}
+	return &res{ast: &cp, result: r}, nil
+}
+
+// CallMap calls the Function with the arguments contained in the map.
+// Each key is the name of a parameter and each value is an expression evaluated before the call.
+func (f *Function) CallMap(args map[string]string) (Result, error) {
+	if len(f.params) != len(args) {
+		return nil, errors.Join(ErrInvalidFunctionCall, errors.New("not all parameters have been defined"))
+	}
+	values := make([]*math.Complex, len(f.params))
+	for i, p := range f.params {
+		v, ok := args[p]
+		if !ok {
+			return nil, errors.Join(ErrInvalidFunctionCall, fmt.Errorf("missing argument for %s", p))
 		}
-		cp := expression
-		for _, p := range params {
-			v, ok := args[p]
-			if !ok {
-				return nil, errors.Join(ErrInvalidFunctionCall, fmt.Errorf("missing argument for %s", p))
-			}
-			cp = strings.ReplaceAll(cp, p, v)
+		r, err := evalArgument(v)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidFunctionCall, fmt.Errorf("invalid argument for %s", p), err)
 		}
-		return Parse(cp)
-	}, len(params), nil
+		values[i] = r
+	}
+	return f.call(values)
+}
+
+// checkParameter returns an error if p is not a valid parameter name
+func checkParameter(p string, params []string) error {
+	tkl, err := lexer.Lex(p)
+	if err != nil {
+		return errors.Join(ErrInvalidFunction, err)
+	}
+	if !tkl.Next() || tkl.Current().Type != lexer.Literal || tkl.Next() {
+		return errors.Join(ErrInvalidFunction, fmt.Errorf("invalid parameter name '%s'", p))
+	}
+	if expression.IsPredefinedVariable(p) || expression.IsPredefinedFunction(p) || ast.IsKeyword(p) {
+		return errors.Join(ErrInvalidFunction, fmt.Errorf("parameter %s is already defined by GoMath", p))
+	}
+	for _, param := range params {
+		if param == p {
+			return errors.Join(ErrInvalidFunction, fmt.Errorf("parameter %s is defined twice", p))
+		}
+	}
+	return nil
+}
+
+// evalArgument returns the number represented by the given expression.
+// Returns ErrInvalidFunctionCall if it is not a number, like 1 < 2.
+func evalArgument(s string) (*math.Complex, error) {
+	tree, err := parseAst(s, ast.TypeCalculation, nil)
+	if err != nil {
+		return nil, err
+	}
+	r, err := tree.Body.Eval(&ast.Options{})
+	if err != nil {
+		return nil, err
+	}
+	z := r.Complex()
+	if z == nil {
+		return nil, errors.Join(ErrInvalidFunctionCall, fmt.Errorf("%s is not a number", s))
+	}
+	return z, nil
 }
@@ -1,16 +1,22 @@
 package gomath
 
-import "testing"
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
 
 func TestNewFunction(t *testing.T) {
-	f, n, err := NewFunction("x -> x^2")
+	f, err := NewFunction("x -> x^2")
 	if err != nil {
 		t.Fatal(err)
 	}
-	if n != 1 {
+	if n := f.Arity(); n != 1 {
 		t.Errorf("got %d, want 1", n)
 	}
-	result, err := f(map[string]string{"x": "5"})
+	result, err := f.CallMap(map[string]string{"x": "5"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -18,14 +24,14 @@ func TestNewFunction(t *testing.T) {
 		t.Errorf("got %s, want 25", result.String())
 	}
 
-	f, n, err = NewFunction("x, y -> x^y")
+	f, err = NewFunction("x, y -> x^y")
 	if err != nil {
 		t.Fatal(err)
 	}
-	if n != 2 {
+	if n := f.Arity(); n != 2 {
 		t.Errorf("got %d, want 2", n)
 	}
-	result, err = f(map[string]string{"x": "5", "y": "2"})
+	result, err = f.CallMap(map[string]string{"x": "5", "y": "2"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,3 +39,158 @@ func TestNewFunction(t *testing.T) {
 		t.Errorf("got %s, want 25", result.String())
 	}
 }
+
+func TestNewFunction_Binding(t *testing.T) {
+	f, err := NewFunction("x -> x^2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := f.CallMap(map[string]string{"x": "-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.String() != "4" {
+		t.Errorf("got %s, want 4", result.String())
+	}
+
+	f, err = NewFunction("x -> exp(x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = f.CallMap(map[string]string{"x": "0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.String() != "1" {
+		t.Errorf("got %s, want 1", result.String())
+	}
+
+	f, err = NewFunction("x -> x + y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.CallMap(map[string]string{"x": "1"})
+	if !errors.Is(err, expression.ErrUnknownVariable) {
+		t.Errorf("got %v, want %v", err, expression.ErrUnknownVariable)
+	}
+}
+
+func TestNewFunction_Errors(t *testing.T) {
+	for _, s := range []string{"x^2", "x, x -> x", "pi -> pi", "2 -> 2", "x+y -> x"} {
+		_, err := NewFunction(s)
+		if !errors.Is(err, ErrInvalidFunction) {
+			t.Errorf("%s: got %v, want %v", s, err, ErrInvalidFunction)
+		}
+	}
+}
+
+func TestFunction_Call(t *testing.T) {
+	f, err := NewFunction("x, y -> x^y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := f.Params(); len(p) != 2 || p[0] != "x" || p[1] != "y" {
+		t.Errorf("got %v, want [x y]", p)
+	}
+	for i := int64(1); i <= 10; i++ {
+		result, err := f.Call(math.IntToFraction(i), math.IntToFraction(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := math.IntToFraction(i * i).String(); result.String() != expected {
+			t.Errorf("got %s, want %s", result.String(), expected)
+		}
+	}
+	_, err = f.Call(math.OneFraction)
+	if !errors.Is(err, ErrInvalidFunctionCall) {
+		t.Errorf("got %v, want %v", err, ErrInvalidFunctionCall)
+	}
+	if _, err = f.Call(nil); !errors.Is(err, ErrInvalidFunctionCall) {
+		t.Errorf("got %v, want %v", err, ErrInvalidFunctionCall)
+	}
+	if _, err = f.Call(math.OneFraction, nil); !errors.Is(err, ErrInvalidFunctionCall) {
+		t.Errorf("got %v, want %v", err, ErrInvalidFunctionCall)
+	}
+	if _, err = f.CallMap(nil); !errors.Is(err, ErrInvalidFunctionCall) {
+		t.Errorf("got %v, want %v", err, ErrInvalidFunctionCall)
+	}
+	if _, err = f.CallMap(map[string]string{"x": "1 < 2", "y": "1"}); !errors.Is(err, ErrInvalidFunctionCall) {
+		t.Errorf("got %v, want %v", err, ErrInvalidFunctionCall)
+	}
+}
+
+func TestFunction_Register(t *testing.T) {
+	reg := expression.DefaultRegistry.Clone()
+	opt := &ast.Options{Registry: reg}
+	f, err := NewFunction("x, y -> x^y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Register("f", reg); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Parse("f(2, 3) + 1", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "9" {
+		t.Errorf("got %s, want 9", r.String())
+	}
+	latex, err := ParseAndConvertToLaTeX("f(2, 3) + 1", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latex != `f\left(2, 3\right) + 1` {
+		t.Errorf("got %s, want %s", latex, `f\left(2, 3\right) + 1`)
+	}
+
+	// a function using another one
+	g, err := NewFunction("x -> f(x, 2) - x", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.Register("g", reg); err != nil {
+		t.Fatal(err)
+	}
+	r, err = Parse("g(5)", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "20" {
+		t.Errorf("got %s, want 20", r.String())
+	}
+
+	if _, err = Parse("f(1)", opt); !errors.Is(err, expression.ErrInvalidArity) {
+		t.Errorf("excepted invalid arity error, got %v", err)
+	}
+}
+
+func TestFunction_RegisterRecursive(t *testing.T) {
+	reg := expression.DefaultRegistry.Clone()
+	opt := &ast.Options{Registry: reg}
+	fact, err := NewFunction("n -> if(n <= 1, 1, n*fact(n - 1))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = fact.Register("fact", reg); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Parse("fact(20)", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "2432902008176640000" {
+		t.Errorf("got %s, want 2432902008176640000", r.String())
+	}
+
+	loop, err := NewFunction("n -> loop(n + 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = loop.Register("loop", reg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Parse("loop(0)", opt); !errors.Is(err, expression.ErrRecursionLimit) {
+		t.Errorf("excepted recursion limit error, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	m "math"
+	"testing"
+)
+
+func TestIntegrate(t *testing.T) {
+	genericTest := func(exp, a, b string, precision int, excepted string) {
+		i, err := Integrate(exp, "x", a, b, precision)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if got := i.Approx(precision); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+		if i.Error > m.Pow10(-precision) {
+			t.Errorf("%s: the error estimate %g is greater than 1e-%d", exp, i.Error, precision)
+		}
+	}
+	genericTest("x^2", "0", "1", 6, "0.333333")
+	genericTest("sin(x)", "0", "pi", 6, "2")
+	genericTest("exp(-x^2)", "-6", "6", 9, "1.772453851")
+	genericTest("1/x", "1", "e", 6, "1")
+	genericTest("sqrt(1 - x^2)", "-1", "1", 4, "1.5708")
+	genericTest("sqrt(x)", "0", "1", 20, "0.66666666666666666667")
+	genericTest("x^2", "0", "1", 30, "0.333333333333333333333333333333")
+
+	scope := expression.NewScope(nil)
+	scope.SetFraction("k", math.IntToFraction(3))
+	i, err := Integrate("k*x", "x", "0", "2", 6, &ast.Options{Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := i.Approx(6); got != "6" {
+		t.Errorf("got %s; want 6", got)
+	}
+
+	if _, err = Integrate("1/x", "x", "-1", "1", 6); !errors.Is(err, math.ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+	if _, err = Integrate("x", "x", "0", "i", 6); !errors.Is(err, expression.ErrNumberNotInSpace) {
+		t.Errorf("excepted number not in space, got %v", err)
+	}
+}
+
+func TestEvalIntegral(t *testing.T) {
+	genericTest := func(exp, excepted string) {
+		r, err := Parse(exp)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if got := r.Approx(6); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+	}
+	genericTest("integrate(x^2, x, 0, 3)", "9")
+	genericTest("2*integrate(cos(t), t, 0, pi/2) + 1", "3")
+	genericTest("integrate(integrate(x*y, x, 0, 1), y, 0, 2)", "1")
+	genericTest("integrate(x^2, x, 0, 1)*3", "1")
+
+	// the quadrature is never exact
+	r, err := Parse("integrate(x^2, x, 0, 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.IsExact(6) {
+		t.Errorf("integrate(x^2, x, 0, 1) must not be exact, got %s", r)
+	}
+	if got := r.String(); got != "integrate(x^2, x, 0, 1)" {
+		t.Errorf("got %s; want integrate(x^2, x, 0, 1)", got)
+	}
+
+	genericTestRenderLatex(t, "integrate(x^2 + 1, x, 0, pi/2)", `\int_{0}^{\frac{\pi}{2}} x^2 + 1 \, dx`)
+
+	if _, err = Parse("integrate(x^2, 2, 0, 1)"); !errors.Is(err, ast.ErrInvalidExpression) {
+		t.Errorf("excepted invalid expression, got %v", err)
+	}
+	if _, err = Parse("integrate(x^2, x, 0)"); !errors.Is(err, ast.ErrInvalidExpression) {
+		t.Errorf("excepted invalid expression, got %v", err)
+	}
+}
@@ -3,8 +3,8 @@ package lexer
 import (
 	"errors"
 	"fmt"
+	"github.com/nyttikord/gomath/math"
 	"slices"
-	"strconv"
 	"strings"
 )
 
@@ -18,11 +18,15 @@ const (
 )
 
 var (
-	operators  = []string{"+", "-", "*", "/", "^", "%", "=", "!"}
-	separators = []string{",", "(", ")"}
+	operators = []string{"+", "-", "*", "/", "^", "%", "=", "!", "<", ">", "°"}
+	// composedOperators are the operators written with two runes
+	composedOperators = []string{"<=", ">=", "==", "!="}
+	separators        = []string{",", "(", ")"}
 
 	// ErrSameTypeFollow is thrown when two numbers follow each others
 	ErrSameTypeFollow = errors.New("sequence of two with exclusively numbers")
+	// ErrMalformedNumber is thrown when a number has a misplaced decimal point, like 1.2.3 or 1e2.5
+	ErrMalformedNumber = errors.New("malformed number")
 )
 
 type Lexer struct {
@@ -34,6 +38,9 @@ type Lexer struct {
 func Lex(content string) (*TokenList, error) {
 	var lexr []*Lexer
 	for _, w := range strings.Split(content, " ") {
+		if w == "" {
+			continue
+		}
 		word, err := lexWord(w)
 		if err != nil {
 			return nil, err
@@ -77,6 +84,7 @@ func lexWord(w string) ([]*Lexer, error) {
 	}
 	var lexers []*Lexer
 	isDecimal := false
+	hasExponent := false
 	content := ""
 	var precType lexType
 
@@ -89,6 +97,8 @@ func lexWord(w string) ([]*Lexer, error) {
 		}
 		content = ""
 		precType = newType
+		isDecimal = false
+		hasExponent = false
 	}
 
 	fnUpdateUnique := func(typ lexType) {
@@ -100,17 +110,44 @@ func lexWord(w string) ([]*Lexer, error) {
 		}
 		content = ""
 		precType = typ
+		isDecimal = false
+		hasExponent = false
 	}
 
-	for _, c := range []rune(w) {
-		if isDigit(string(c)) || (c == '.' && !isDecimal) {
-			if !isDecimal {
-				isDecimal = c == '.'
+	runes := []rune(w)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if n := exponentLength(runes[i:]); precType == Number && !hasExponent && n > 0 {
+			// scientific notation, like 6.02e23
+			hasExponent = true
+			isDecimal = true
+			content += string(runes[i : i+n])
+			i += n - 1
+			continue
+		}
+		if precType == Number && isDecimal && c == '.' {
+			end := i
+			for end < len(runes) && (runes[end] == '.' || isDigit(string(runes[end]))) {
+				end++
 			}
-			if content == "" {
+			return nil, errors.Join(
+				ErrMalformedNumber,
+				fmt.Errorf("%s%s is not a valid number", content, string(runes[i:end])),
+			)
+		}
+		if precType == Literal && isDigit(string(c)) {
+			// digits following letters are part of the literal, like log10
+			fnUpdate(Literal)
+		} else if isDigit(string(c)) || c == '.' {
+			fnUpdate(Number)
+			if content == "" && c == '.' {
 				content += "0" // turns .5 into 0.5
 			}
-			fnUpdate(Number)
+			if !isDecimal {
+				isDecimal = c == '.'
+			}
+		} else if precType == Operator && slices.Contains(composedOperators, content+string(c)) {
+			// continues the operator, like <=
 		} else if isOperator(c) {
 			fnUpdateUnique(Operator)
 		} else if isSeparator(c) {
@@ -123,10 +160,29 @@ func lexWord(w string) ([]*Lexer, error) {
 	return append(lexers, &Lexer{precType, content}), nil
 }
 
-// isDigit checks if the string contains a digit
+// isDigit checks if the string is a decimal number
 func isDigit(s string) bool {
-	_, err := strconv.ParseFloat(s, 64)
-	return err == nil
+	return math.IsDecimal(s)
+}
+
+// exponentLength returns the length of the exponent starting the runes, like e23 or E-5.
+// Returns 0 if the runes do not start with an exponent.
+func exponentLength(runes []rune) int {
+	if len(runes) < 2 || (runes[0] != 'e' && runes[0] != 'E') {
+		return 0
+	}
+	n := 1
+	if runes[n] == '+' || runes[n] == '-' {
+		n++
+	}
+	start := n
+	for n < len(runes) && runes[n] >= '0' && runes[n] <= '9' {
+		n++
+	}
+	if n == start {
+		return 0
+	}
+	return n
 }
 
 // isOperator checks if the rune is an operator
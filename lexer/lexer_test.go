@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -284,3 +285,112 @@ func printLex(t *testing.T, lexr []*Lexer) {
 	}
 	t.Log(s[:len(s)-1])
 }
+
+func TestLexerScientificNotation(t *testing.T) {
+	genericTest := func(s string, expected ...string) {
+		res, err := Lex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lexr := res.list
+		if len(lexr) != len(expected) {
+			t.Errorf("Lexer has wrong length, got %d, excepted %d", len(lexr), len(expected))
+			printLex(t, lexr)
+			return
+		}
+		for i, v := range expected {
+			if lexr[i].Value != v {
+				t.Errorf("got %s; want %s", lexr[i].Value, v)
+			}
+		}
+		if t.Failed() {
+			printLex(t, lexr)
+		}
+	}
+	genericTest("6.02e23", "6.02e23")
+	genericTest("1+6.02e23*2", "1", "+", "6.02e23", "*", "2")
+	genericTest("3e-2x", "3e-2", "x")
+	genericTest("2e", "2", "e")
+	genericTest("2ex", "2", "ex")
+	genericTest("1.5+2.5", "1.5", "+", "2.5")
+	genericTest("2e-1", "2e-1")
+	genericTest("2e+1", "2e+1")
+
+	for _, s := range []string{"1.2.3", "x+1..2", "1e2.5"} {
+		if _, err := Lex(s); !errors.Is(err, ErrMalformedNumber) {
+			t.Errorf("%s: excepted malformed number, got %v", s, err)
+		}
+	}
+}
+
+func TestLexerLiteralWithDigits(t *testing.T) {
+	res, err := Lex("log10(2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lexr := res.list
+	if lexr[0].Type != Literal || lexr[0].Value != "log10" {
+		t.Error("expecting literal(log10), got", lexr[0])
+	}
+	res, err = Lex("2x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lexr = res.list
+	if lexr[0].Type != Number || lexr[1].Type != Literal {
+		t.Error("expecting number(2) literal(x), got", lexr)
+	}
+}
+
+func TestLexerComparison(t *testing.T) {
+	genericTest := func(s string, excepted ...string) {
+		res, err := Lex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := res.list
+		if len(l) != len(excepted) {
+			t.Errorf("Lexer has wrong length, got %d, excepted %d", len(l), len(excepted))
+			printLex(t, l)
+			return
+		}
+		for i, v := range excepted {
+			if l[i].Value != v {
+				t.Errorf("got %s; want %s", l[i].Value, v)
+			}
+		}
+	}
+	genericTest("x<=3", "x", "<=", "3")
+	genericTest("x>-3", "x", ">", "-", "3")
+	genericTest("x!=3", "x", "!=", "3")
+	genericTest("2==2", "2", "==", "2")
+	genericTest("3!+1>=2", "3", "!", "+", "1", ">=", "2")
+	genericTest("x>3 and x<5", "x", ">", "3", "and", "x", "<", "5")
+	// the spaces do not create empty tokens
+	genericTest("1 <= ", "1", "<=")
+	genericTest(" 1  ==  2", "1", "==", "2")
+}
+
+func TestLexerDegree(t *testing.T) {
+	genericTest := func(s string, expected ...*Lexer) {
+		res, err := Lex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lexr := res.list
+		if len(lexr) != len(expected) {
+			t.Errorf("Lexer has wrong length, got %d, excepted %d", len(lexr), len(expected))
+			printLex(t, lexr)
+			return
+		}
+		for i, v := range expected {
+			if lexr[i].Type != v.Type || lexr[i].Value != v.Value {
+				t.Errorf("got %s; want %s", lexr[i], v)
+			}
+		}
+	}
+	genericTest("30°", &Lexer{Number, "30"}, &Lexer{Operator, "°"})
+	genericTest("a°", &Lexer{Literal, "a"}, &Lexer{Operator, "°"})
+	genericTest("sin(x°)", &Lexer{Literal, "sin"}, &Lexer{Separator, "("}, &Lexer{Literal, "x"}, &Lexer{Operator, "°"},
+		&Lexer{Separator, ")"})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/nyttikord/gomath/ast"
 	"github.com/nyttikord/gomath/lexer"
+	"github.com/nyttikord/gomath/math"
 )
 
 var (
@@ -14,19 +15,39 @@ var (
 // You can directly get the exact result with String or with fmt.Sprintf("%s", result)
 type Result interface {
 	// String returns the string representation of the Result.
-	// It is the exact result (fraction form)
+	// It is the exact result (fraction form, irrational constants like pi are kept symbolic, complex numbers are
+	// written a + bi)
 	String() string
-	// Approx returns an approximation of the Result given by String()
+	// Approx returns an approximation of the Result given by String(), rounded with the Rounding of the Options
 	Approx(int) string
-	// LaTeX returns the LaTeX representation of the expression leading to the Result
+	// ApproxWith returns an approximation of the Result given by String() rounded with the given math.Rounding
+	ApproxWith(int, math.Rounding) string
+	// LaTeX returns the LaTeX representation of the exact result, like ExactLaTeX.
+	// Use ParseAndConvertToLaTeX to get the one of the expression leading to the Result, like \sqrt{8}.
 	LaTeX() (string, error)
-	// IsExact returns true if the fraction can be exactly represented by a string
+	// ExactLaTeX returns the LaTeX representation of the exact result, like 2\sqrt{2} for sqrt(8)
+	ExactLaTeX() string
+	// IsExact returns true if the result can be exactly represented by a string with the given precision.
+	// It is always false for irrational results.
 	IsExact(int) bool
+	// Boolean returns the truth value of the Result if the expression was a condition, like 2 < 3.
+	// The second value is false if the expression was not a condition.
+	Boolean() (bool, bool)
+	// Quantity returns the physical quantity of the Result if the expression used units, like 3 m + 20 cm.
+	// The second value is false if the Result is not a quantity having a unit.
+	Quantity() (*math.Quantity, bool)
+	// Money returns the exact amount of the Result if the expression was evaluated in the money mode, like 25/2 EUR.
+	// The second value is false if the money mode was disabled.
+	Money() (*math.Money, bool)
 }
 
 type res struct {
 	ast    *ast.Ast
 	result *ast.StatementResult
+	// rounding is the rule used by Approx
+	rounding math.Rounding
+	// money contains the options of the money mode (nil if it is disabled)
+	money *ast.MoneyOptions
 }
 
 func (r *res) String() string {
@@ -34,50 +55,103 @@ func (r *res) String() string {
 }
 
 func (r *res) Approx(precision int) string {
-	f := r.result.Fraction()
-	if f == nil {
+	if m, ok := r.Money(); ok {
+		return m.FixedString(precision, r.money.Rounding)
+	}
+	return r.ApproxWith(precision, r.rounding)
+}
+
+func (r *res) ApproxWith(precision int, rounding math.Rounding) string {
+	if _, ok := r.result.Boolean(); ok {
+		return r.result.String()
+	}
+	if m, ok := r.Money(); ok {
+		return m.ApproxWith(precision, rounding)
+	}
+	if q, ok := r.Quantity(); ok {
+		return q.ApproxWith(precision, rounding)
+	}
+	n := r.result.Complex()
+	if n == nil {
 		panic(ErrInvalidResult)
 	}
-	return f.Approx(precision)
+	return n.ApproxWith(precision, rounding)
 }
 
 func (r *res) IsExact(precision int) bool {
-	f := r.result.Fraction()
-	if f == nil {
+	if _, ok := r.result.Boolean(); ok {
+		return true
+	}
+	if m, ok := r.Money(); ok {
+		return m.Amount.CanBeRepresentedExactly(precision)
+	}
+	if q, ok := r.Quantity(); ok {
+		return q.CanBeRepresentedExactly(precision)
+	}
+	n := r.result.Complex()
+	if n == nil {
 		panic(ErrInvalidResult)
 	}
 
-	return f.CanBeRepresentedExactly(precision)
+	return n.CanBeRepresentedExactly(precision)
 }
 
-func (r *res) LaTeX() (string, error) {
-	err := r.ast.ChangeType(ast.TypeLatex)
-	if err != nil {
-		return "", err
+func (r *res) ExactLaTeX() string {
+	if _, ok := r.result.Boolean(); ok {
+		return `\text{` + r.result.String() + "}"
 	}
-	result, err := r.ast.Body.Eval(&ast.Options{})
-	if err != nil {
-		return "", err
+	if m, ok := r.Money(); ok {
+		return m.LaTeX(r.money.Decimals, r.money.Rounding)
 	}
-	return result.String(), nil
+	if q, ok := r.Quantity(); ok {
+		return q.LaTeX()
+	}
+	n := r.result.Complex()
+	if n == nil {
+		panic(ErrInvalidResult)
+	}
+	return n.LaTeX()
+}
+
+func (r *res) Boolean() (bool, bool) {
+	return r.result.Boolean()
+}
+
+func (r *res) Quantity() (*math.Quantity, bool) {
+	q := r.result.Quantity()
+	return q, q != nil
 }
 
-// Parse the given expression and return the Result obtained
-func Parse(expression string) (Result, error) {
-	tree, err := parseAst(expression, ast.TypeCalculation)
+func (r *res) Money() (*math.Money, bool) {
+	m := r.result.Money()
+	return m, m != nil
+}
+
+func (r *res) LaTeX() (string, error) {
+	return r.ExactLaTeX(), nil
+}
+
+// Parse the given expression and return the Result obtained.
+// The optional Options give the Scope and the expression.Registry used (the Decimal option is ignored: use Approx).
+func Parse(expression string, opts ...*ast.Options) (Result, error) {
+	opt := &ast.Options{}
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	tree, err := parseAst(expression, ast.TypeCalculation, opt)
 	if err != nil {
 		return nil, err
 	}
-	r, err := tree.Body.Eval(&ast.Options{Decimal: false})
+	r, err := tree.Body.Eval(&ast.Options{Scope: opt.Scope, Registry: opt.Registry, Angle: opt.Angle, Money: opt.Money})
 	if err != nil {
 		return nil, err
 	}
-	return &res{ast: tree, result: r}, nil
+	return &res{ast: tree, result: r, rounding: opt.Rounding, money: opt.Money}, nil
 }
 
 // ParseAndCalculate an expression with given Options
 func ParseAndCalculate(expression string, opt *ast.Options) (string, error) {
-	tree, err := parseAst(expression, ast.TypeCalculation)
+	tree, err := parseAst(expression, ast.TypeCalculation, opt)
 	if err != nil {
 		return "", err
 	}
@@ -90,7 +164,7 @@ func ParseAndCalculate(expression string, opt *ast.Options) (string, error) {
 
 // ParseAndConvertToLaTeX an expression with given Options
 func ParseAndConvertToLaTeX(expression string, opt *ast.Options) (string, error) {
-	tree, err := parseAst(expression, ast.TypeLatex)
+	tree, err := parseAst(expression, ast.TypeLatex, opt)
 	if err != nil {
 		return "", err
 	}
@@ -101,10 +175,14 @@ func ParseAndConvertToLaTeX(expression string, opt *ast.Options) (string, error)
 	return result.String(), nil
 }
 
-func parseAst(expression string, tpe ast.Type) (*ast.Ast, error) {
+// parseAst parses the expression with the expression.Registry of opt (can be nil)
+func parseAst(expression string, tpe ast.Type, opt *ast.Options) (*ast.Ast, error) {
 	lexed, err := lexer.Lex(expression)
 	if err != nil {
 		return nil, err
 	}
-	return ast.Parse(lexed, tpe)
+	if opt == nil {
+		return ast.Parse(lexed, tpe)
+	}
+	return ast.ParseWithRegistry(lexed, tpe, opt.ParseRegistry())
 }
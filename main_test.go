@@ -1,7 +1,10 @@
 package gomath
 
 import (
+	"errors"
 	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
 	"testing"
 )
 
@@ -27,6 +30,46 @@ func TestRes_Approx(t *testing.T) {
 	}
 }
 
+func TestRes_ApproxRounding(t *testing.T) {
+	opt := &ast.Options{Rounding: math.Rounding{Mode: math.HalfEven, KeepZeros: true}}
+	r, err := Parse("5/8", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excepted := "0.62"
+	got := r.Approx(2)
+	if excepted != got {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = "0.6250"
+	got = r.Approx(4)
+	if excepted != got {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = "0.6"
+	got = r.ApproxWith(1, math.Rounding{Mode: math.Floor})
+	if excepted != got {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+
+	excepted = "-0.63"
+	got, err = ParseAndCalculate("-5/8", &ast.Options{Decimal: true, Precision: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if excepted != got {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = "-0.62"
+	got, err = ParseAndCalculate("-5/8", &ast.Options{Decimal: true, Precision: 2, Rounding: math.Rounding{Mode: math.HalfDown}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if excepted != got {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+}
+
 func TestRes_IsExact(t *testing.T) {
 	r, err := Parse("3/4")
 	if err != nil {
@@ -61,3 +104,141 @@ func TestRes_LaTeX(t *testing.T) {
 		t.Errorf("excepted: %s, got: %s", excepted, got)
 	}
 }
+
+func TestRes_Irrational(t *testing.T) {
+	r, err := Parse("3pi/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "3pi/2" {
+		t.Errorf("excepted: %s, got: %s", "3pi/2", r.String())
+	}
+	excepted := "4.71238898038468985769"
+	if got := r.Approx(20); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	if r.IsExact(100) {
+		t.Errorf("excepted: %t, got: %t", false, true)
+	}
+}
+
+func TestRes_ExactLaTeX(t *testing.T) {
+	r, err := Parse("sqrt(8)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "2sqrt(2)" {
+		t.Errorf("excepted: %s, got: %s", "2sqrt(2)", r.String())
+	}
+	excepted := `2\sqrt{2}`
+	if got := r.ExactLaTeX(); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	// LaTeX renders the exact result too, the expression is rendered by ParseAndConvertToLaTeX
+	got, err := r.LaTeX()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = `\sqrt{8}`
+	if got, err = ParseAndConvertToLaTeX("sqrt(8)", &ast.Options{}); err != nil || got != excepted {
+		t.Errorf("excepted: %s, got: %s (%v)", excepted, got, err)
+	}
+}
+
+func TestRes_Complex(t *testing.T) {
+	r, err := Parse("1/2 + sqrt(-4)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	excepted := "1/2 + 2i"
+	if got := r.String(); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = "0.5 + 2i"
+	if got := r.Approx(3); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = `\frac{1}{2} + 2i`
+	if got := r.ExactLaTeX(); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	if !r.IsExact(1) {
+		t.Errorf("excepted: %t, got: %t", true, false)
+	}
+}
+
+func TestRes_Boolean(t *testing.T) {
+	r, err := Parse("sqrt(2) < 3/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := r.Boolean(); !ok || !b {
+		t.Errorf("excepted: %t, got: %t (condition: %t)", true, b, ok)
+	}
+	excepted := `\text{true}`
+	if got := r.ExactLaTeX(); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	r, err = Parse("sqrt(2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Boolean(); ok {
+		t.Errorf("excepted: %t, got: %t", false, ok)
+	}
+}
+
+func TestParse_Registry(t *testing.T) {
+	reg := expression.DefaultRegistry.Clone()
+	err := reg.SetConstant("g", math.FractionToComplex(math.NewFraction(981, 100)), `\mathrm{g}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = reg.SetFunction("vat", &math.RealSet{}, func(r *math.Real) (*math.Real, error) {
+		return r.Mul(math.FractionToReal(math.NewFraction(6, 5))), nil
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := &ast.Options{Registry: reg}
+	r, err := Parse("vat(10) + 2g", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	excepted := "1581/50"
+	if got := r.String(); got != excepted {
+		t.Errorf("excepted: %s, got: %s", excepted, got)
+	}
+	excepted = `\operatorname{vat}\left(10\right) + 2 \times \mathrm{g}`
+	if got, err := ParseAndConvertToLaTeX("vat(10) + 2g", opt); err != nil || got != excepted {
+		t.Errorf("excepted: %s, got: %s (%v)", excepted, got, err)
+	}
+
+	// the default registry is not modified
+	if _, err = Parse("vat(10)"); !errors.Is(err, expression.ErrUnknownVariable) {
+		t.Errorf("excepted unknown variable error, got %v", err)
+	}
+	if err = reg.SetConstant("vat", math.OneComplex, ""); !errors.Is(err, expression.ErrInvalidName) {
+		t.Errorf("excepted invalid name error, got %v", err)
+	}
+
+	// a nil domain is R
+	err = reg.SetFunction("half", nil, func(r *math.Real) (*math.Real, error) {
+		return r.Mul(math.FractionToReal(math.NewFraction(1, 2))), nil
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err = Parse("half(-3)", opt); err != nil || r.String() != "-3/2" {
+		t.Errorf("excepted: -3/2, got: %v (%v)", r, err)
+	}
+	if err = reg.SetFunction("bad", &math.RealSet{}, nil, ""); !errors.Is(err, expression.ErrInvalidDefinition) {
+		t.Errorf("excepted invalid definition error, got %v", err)
+	}
+	if err = reg.SetConstant("k", nil, ""); !errors.Is(err, expression.ErrInvalidDefinition) {
+		t.Errorf("excepted invalid definition error, got %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package math
+
+import (
+	"math/big"
+)
+
+// This file contains the arbitrary-precision implementations of the transcendental functions.
+// Each function takes the precision (in bits) of the result and computes with guard bits to absorb rounding errors.
+
+var (
+	ln2 = &constant{name: "ln(2)", latex: `\ln\left(2\right)`, compute: computeLn2}
+)
+
+// computeLn2 computes ln(2) = 2 atanh(1/3)
+func computeLn2(prec uint) *big.Float {
+	wp := prec + guardBits
+	z := new(big.Float).SetPrec(wp).Quo(big.NewFloat(1), big.NewFloat(3))
+	r := atanhSeries(z, wp)
+	return r.Mul(r, big.NewFloat(2)).SetPrec(prec)
+}
+
+// atanhSeries computes atanh(z) = sum(z^(2k+1) / (2k+1)), with |z| < 1
+func atanhSeries(z *big.Float, prec uint) *big.Float {
+	sum := new(big.Float).SetPrec(prec)
+	pow := new(big.Float).SetPrec(prec).Set(z)
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	t := new(big.Float).SetPrec(prec)
+	for k := int64(0); ; k++ {
+		t.Quo(pow, new(big.Float).SetInt64(2*k+1))
+		if t.Sign() == 0 || t.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			return sum
+		}
+		sum.Add(sum, t)
+		pow.Mul(pow, z2)
+	}
+}
+
+// sqrtFloat computes the square root of x >= 0
+func sqrtFloat(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+	return new(big.Float).SetPrec(prec).Sqrt(x)
+}
+
+// expFloat computes e^x.
+//
+// x is divided by 2^s to be small enough for the Taylor series, then the result is squared s times.
+func expFloat(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+	s := max(x.MantExp(nil)+8, 0)
+	wp := prec + guardBits + uint(s)
+	y := new(big.Float).SetPrec(wp).SetMantExp(x, -s)
+	sum := new(big.Float).SetPrec(wp).SetInt64(1)
+	t := new(big.Float).SetPrec(wp).SetInt64(1)
+	for k := int64(1); ; k++ {
+		t.Mul(t, y)
+		t.Quo(t, new(big.Float).SetInt64(k))
+		if t.Sign() == 0 || t.MantExp(nil) < -int(wp) {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	for ; s > 0; s-- {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetPrec(prec)
+}
+
+// lnFloat computes ln(x), with x > 0.
+//
+// x = m * 2^e with m in [sqrt(2)/2 ; sqrt(2)[, so ln(x) = ln(m) + e*ln(2) and ln(m) = 2 atanh((m-1)/(m+1)).
+func lnFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+	m := new(big.Float).SetPrec(wp)
+	e := x.MantExp(m)
+	// m is in [0.5 ; 1[: it is moved closer to 1 to prevent any cancellation when x is close to 1
+	if m.Cmp(big.NewFloat(0.7071)) < 0 {
+		m.Mul(m, big.NewFloat(2))
+		e--
+	}
+	num := new(big.Float).SetPrec(wp).Sub(m, big.NewFloat(1))
+	den := new(big.Float).SetPrec(wp).Add(m, big.NewFloat(1))
+	r := atanhSeries(num.Quo(num, den), wp)
+	r.Mul(r, big.NewFloat(2))
+	if e != 0 {
+		l := ln2.approx(wp)
+		r.Add(r, l.Mul(l, new(big.Float).SetInt64(int64(e))))
+	}
+	return r.SetPrec(prec)
+}
+
+// logFloat computes the logarithm of x > 0 in the given base
+func logFloat(x *big.Float, base int64, prec uint) *big.Float {
+	wp := prec + guardBits
+	r := lnFloat(x, wp)
+	return r.Quo(r, lnFloat(new(big.Float).SetInt64(base), wp)).SetPrec(prec)
+}
+
+// reduceAngle returns x modulo 2pi, in [-pi ; pi]
+func reduceAngle(x *big.Float, prec uint) *big.Float {
+	// the absolute error of the reduction depends on the magnitude of x
+	wp := prec + guardBits + uint(max(x.MantExp(nil), 0))
+	twoPi := new(big.Float).SetPrec(wp).Mul(Pi.approx(wp), big.NewFloat(2))
+	q := new(big.Float).SetPrec(wp).Quo(x, twoPi)
+	// rounds q to the nearest integer
+	n, _ := q.Add(q, big.NewFloat(0.5)).Int(nil)
+	if q.Sign() < 0 && !q.IsInt() {
+		n.Sub(n, big.NewInt(1))
+	}
+	r := new(big.Float).SetPrec(wp).SetInt(n)
+	r.Mul(r, twoPi)
+	return r.Sub(x, r).SetPrec(prec + guardBits)
+}
+
+// sinCosFloat computes sin(x) and cos(x) with their Taylor series
+func sinCosFloat(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	wp := prec + guardBits
+	y := reduceAngle(x, wp)
+	sin := new(big.Float).SetPrec(wp)
+	cos := new(big.Float).SetPrec(wp)
+	// t is y^k/k!
+	t := new(big.Float).SetPrec(wp).SetInt64(1)
+	for k := int64(0); ; k++ {
+		if k > 0 {
+			t.Mul(t, y)
+			t.Quo(t, new(big.Float).SetInt64(k))
+		}
+		if t.Sign() == 0 || (k > 2 && t.MantExp(nil) < -int(wp)) {
+			break
+		}
+		switch k % 4 {
+		case 0:
+			cos.Add(cos, t)
+		case 1:
+			sin.Add(sin, t)
+		case 2:
+			cos.Sub(cos, t)
+		case 3:
+			sin.Sub(sin, t)
+		}
+	}
+	return sin.SetPrec(prec), cos.SetPrec(prec)
+}
+
+func sinFloat(x *big.Float, prec uint) *big.Float {
+	s, _ := sinCosFloat(x, prec)
+	return s
+}
+
+func cosFloat(x *big.Float, prec uint) *big.Float {
+	_, c := sinCosFloat(x, prec)
+	return c
+}
+
+func tanFloat(x *big.Float, prec uint) *big.Float {
+	s, c := sinCosFloat(x, prec+guardBits)
+	return s.Quo(s, c).SetPrec(prec)
+}
+
+// atanFloat computes atan(x).
+//
+// x is reduced with atan(x) = 2 atan(x/(1 + sqrt(1 + x^2))) until |x| <= 1/2, then the Taylor series is used.
+func atanFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+	y := new(big.Float).SetPrec(wp).Set(x)
+	one := big.NewFloat(1)
+	half := big.NewFloat(0.5)
+	k := 0
+	for new(big.Float).Abs(y).Cmp(half) > 0 {
+		t := new(big.Float).SetPrec(wp).Mul(y, y)
+		t.Add(t, one)
+		t.Sqrt(t)
+		t.Add(t, one)
+		y.Quo(y, t)
+		k++
+	}
+	// sum((-1)^n y^(2n+1) / (2n+1))
+	sum := new(big.Float).SetPrec(wp)
+	pow := new(big.Float).SetPrec(wp).Set(y)
+	y2 := new(big.Float).SetPrec(wp).Mul(y, y)
+	t := new(big.Float).SetPrec(wp)
+	for n := int64(0); ; n++ {
+		t.Quo(pow, new(big.Float).SetInt64(2*n+1))
+		if t.Sign() == 0 || t.MantExp(nil) < sum.MantExp(nil)-int(wp) {
+			break
+		}
+		if n%2 == 0 {
+			sum.Add(sum, t)
+		} else {
+			sum.Sub(sum, t)
+		}
+		pow.Mul(pow, y2)
+	}
+	return sum.SetMantExp(sum, k).SetPrec(prec)
+}
+
+// asinFloat computes asin(x), with |x| <= 1, with asin(x) = 2 atan(x/(1 + sqrt(1 - x^2)))
+func asinFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+	one := big.NewFloat(1)
+	t := new(big.Float).SetPrec(wp).Mul(x, x)
+	t.Sub(one, t)
+	t = sqrtFloat(t, wp)
+	t.Add(t, one)
+	r := atanFloat(t.Quo(x, t), wp)
+	return r.Mul(r, big.NewFloat(2)).SetPrec(prec)
+}
+
+// acosFloat computes acos(x), with |x| <= 1, with acos(x) = 2 atan(sqrt((1 - x)/(1 + x))).
+// It does not subtract asin(x) from pi/2 to prevent any cancellation when x is close to 1.
+func acosFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+	one := big.NewFloat(1)
+	if x.Cmp(big.NewFloat(-1)) == 0 {
+		return Pi.approx(prec)
+	}
+	num := new(big.Float).SetPrec(wp).Sub(one, x)
+	den := new(big.Float).SetPrec(wp).Add(one, x)
+	r := atanFloat(sqrtFloat(num.Quo(num, den), wp), wp)
+	return r.Mul(r, big.NewFloat(2)).SetPrec(prec)
+}
+
+func secFloat(x *big.Float, prec uint) *big.Float {
+	c := cosFloat(x, prec+guardBits)
+	return c.Quo(big.NewFloat(1), c).SetPrec(prec)
+}
+
+func cscFloat(x *big.Float, prec uint) *big.Float {
+	s := sinFloat(x, prec+guardBits)
+	return s.Quo(big.NewFloat(1), s).SetPrec(prec)
+}
+
+func cotFloat(x *big.Float, prec uint) *big.Float {
+	s, c := sinCosFloat(x, prec+guardBits)
+	return c.Quo(c, s).SetPrec(prec)
+}
+
+// smallBits returns the number of bits lost by a cancellation in f(x) when f(x) is close to x, i.e. when x is small
+func smallBits(x *big.Float) uint {
+	if x.Sign() == 0 {
+		return 0
+	}
+	return uint(max(-x.MantExp(nil), 0))
+}
+
+// sinhCoshFloat computes sinh(x) and cosh(x) with e^x and e^-x
+func sinhCoshFloat(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	wp := prec + guardBits + smallBits(x)
+	ex := expFloat(x, wp)
+	inv := new(big.Float).SetPrec(wp).Quo(big.NewFloat(1), ex)
+	half := big.NewFloat(0.5)
+	sinh := new(big.Float).SetPrec(wp).Sub(ex, inv)
+	cosh := new(big.Float).SetPrec(wp).Add(ex, inv)
+	return sinh.Mul(sinh, half).SetPrec(prec), cosh.Mul(cosh, half).SetPrec(prec)
+}
+
+func sinhFloat(x *big.Float, prec uint) *big.Float {
+	s, _ := sinhCoshFloat(x, prec)
+	return s
+}
+
+func coshFloat(x *big.Float, prec uint) *big.Float {
+	_, c := sinhCoshFloat(x, prec)
+	return c
+}
+
+func tanhFloat(x *big.Float, prec uint) *big.Float {
+	s, c := sinhCoshFloat(x, prec+guardBits)
+	return s.Quo(s, c).SetPrec(prec)
+}
+
+// asinhFloat computes asinh(x) = sign(x) ln(|x| + sqrt(x^2 + 1))
+func asinhFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits + smallBits(x)
+	a := new(big.Float).SetPrec(wp).Abs(x)
+	t := new(big.Float).SetPrec(wp).Mul(a, a)
+	t.Add(t, big.NewFloat(1))
+	t = sqrtFloat(t, wp)
+	r := lnFloat(t.Add(t, a), wp)
+	if x.Sign() < 0 {
+		r.Neg(r)
+	}
+	return r.SetPrec(prec)
+}
+
+// acoshFloat computes acosh(x) = ln(x + sqrt(x^2 - 1)), with x >= 1
+func acoshFloat(x *big.Float, prec uint) *big.Float {
+	one := big.NewFloat(1)
+	wp := prec + guardBits + smallBits(new(big.Float).Sub(x, one))
+	t := new(big.Float).SetPrec(wp).Mul(x, x)
+	t.Sub(t, one)
+	t = sqrtFloat(t, wp)
+	return lnFloat(t.Add(t, x), wp).SetPrec(prec)
+}
+
+// atanhFloat computes atanh(x), with |x| < 1.
+// The series is used when |x| <= 1/2, otherwise atanh(x) = ln((1 + x)/(1 - x))/2.
+func atanhFloat(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+	one := big.NewFloat(1)
+	if new(big.Float).Abs(x).Cmp(big.NewFloat(0.5)) <= 0 {
+		return atanhSeries(new(big.Float).SetPrec(wp).Set(x), wp).SetPrec(prec)
+	}
+	num := new(big.Float).SetPrec(wp).Add(one, x)
+	den := new(big.Float).SetPrec(wp).Sub(one, x)
+	r := lnFloat(num.Quo(num, den), wp)
+	return r.Mul(r, big.NewFloat(0.5)).SetPrec(prec)
+}
@@ -0,0 +1,540 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Complex is a complex number re + im*i.
+// Both parts are exact Real (so a Complex built with Fraction stays a pair of Fraction), like 1/2 + 3i or sqrt(2)i.
+type Complex struct {
+	re, im *Real
+}
+
+var (
+	// I is the imaginary unit, i^2 = -1
+	I           = NewComplex(NullReal, OneReal)
+	NullComplex = RealToComplex(NullReal)
+	OneComplex  = RealToComplex(OneReal)
+)
+
+// NewComplex returns the Complex re + im*i
+func NewComplex(re, im *Real) *Complex {
+	return &Complex{re, im}
+}
+
+// RealToComplex converts a Real into a Complex
+func RealToComplex(r *Real) *Complex {
+	return &Complex{r, NullReal}
+}
+
+// FractionToComplex converts a Fraction into a Complex
+func FractionToComplex(f *Fraction) *Complex {
+	return RealToComplex(FractionToReal(f))
+}
+
+// IntToComplex converts an int64 into a Complex
+func IntToComplex(n int64) *Complex {
+	return RealToComplex(IntToReal(n))
+}
+
+// Re returns the real part of the Complex
+func (z *Complex) Re() *Real {
+	return z.re
+}
+
+// Im returns the imaginary part of the Complex
+func (z *Complex) Im() *Real {
+	return z.im
+}
+
+// IsReal returns true if the imaginary part is null
+func (z *Complex) IsReal() bool {
+	return z.im.IsNull()
+}
+
+// Real returns the Real represented by the Complex and true if it is a real number
+func (z *Complex) Real() (*Real, bool) {
+	if !z.IsReal() {
+		return nil, false
+	}
+	return z.re, true
+}
+
+// Fraction returns the Fraction represented by the Complex and true if it is a rational number
+func (z *Complex) Fraction() (*Fraction, bool) {
+	if !z.IsReal() {
+		return nil, false
+	}
+	return z.re.Fraction()
+}
+
+// Is returns true if both Complex are equal
+func (z *Complex) Is(a *Complex) bool {
+	return z.re.Is(a.re) && z.im.Is(a.im)
+}
+
+// IsNull returns true if the Complex is 0
+func (z *Complex) IsNull() bool {
+	return z.re.IsNull() && z.im.IsNull()
+}
+
+// Add a Complex
+func (z *Complex) Add(a *Complex) *Complex {
+	return &Complex{z.re.Add(a.re), z.im.Add(a.im)}
+}
+
+// Neg returns the opposite of the Complex
+func (z *Complex) Neg() *Complex {
+	return &Complex{z.re.Neg(), z.im.Neg()}
+}
+
+// Sub (subtract) a Complex
+func (z *Complex) Sub(a *Complex) *Complex {
+	return z.Add(a.Neg())
+}
+
+// Mul (multiply) by a Complex
+func (z *Complex) Mul(a *Complex) *Complex {
+	if z.IsReal() && a.IsReal() {
+		return RealToComplex(z.re.Mul(a.re))
+	}
+	return &Complex{
+		z.re.Mul(a.re).Sub(z.im.Mul(a.im)),
+		z.re.Mul(a.im).Add(z.im.Mul(a.re)),
+	}
+}
+
+// Conj returns the conjugate of the Complex
+func (z *Complex) Conj() *Complex {
+	return &Complex{z.re, z.im.Neg()}
+}
+
+// Inv (invert) the Complex
+func (z *Complex) Inv() (*Complex, error) {
+	if z.IsReal() {
+		inv, err := z.re.Inv()
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(inv), nil
+	}
+	// 1/(a + bi) = (a - bi)/(a^2 + b^2)
+	n, err := z.norm().Inv()
+	if err != nil {
+		return nil, err
+	}
+	return &Complex{z.re.Mul(n), z.im.Neg().Mul(n)}, nil
+}
+
+// Div (divide) by a Complex
+func (z *Complex) Div(a *Complex) (*Complex, error) {
+	if z.IsReal() && a.IsReal() {
+		res, err := z.re.Div(a.re)
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(res), nil
+	}
+	inv, err := a.Inv()
+	if err != nil {
+		return nil, errors.Join(err, errors.New("cannot divide by a null Complex"))
+	}
+	return z.Mul(inv), nil
+}
+
+// norm returns a^2 + b^2
+func (z *Complex) norm() *Real {
+	return z.re.Mul(z.re).Add(z.im.Mul(z.im))
+}
+
+// Abs returns the modulus of the Complex
+func (z *Complex) Abs() *Real {
+	if z.IsReal() {
+		if z.re.Sign() < 0 {
+			return z.re.Neg()
+		}
+		return z.re
+	}
+	// a^2 + b^2 is positive, so the square root is always defined
+	r, _ := Sqrt(z.norm())
+	return r
+}
+
+// Arg returns the principal argument of the Complex, in ]-pi ; pi].
+// Returns ErrIllegalOperation if the Complex is null.
+func (z *Complex) Arg() (*Real, error) {
+	if z.IsNull() {
+		return nil, errors.Join(ErrIllegalOperation, errors.New("the argument of 0 is not defined"))
+	}
+	piOverTwo, _ := Pi.Div(IntToReal(2))
+	signRe, signIm := z.re.Sign(), z.im.Sign()
+	if signRe == 0 {
+		if signIm > 0 {
+			return piOverTwo, nil
+		}
+		return piOverTwo.Neg(), nil
+	}
+	if signIm == 0 {
+		if signRe > 0 {
+			return NullReal, nil
+		}
+		return Pi, nil
+	}
+	q, err := z.im.Div(z.re)
+	if err != nil {
+		return nil, err
+	}
+	a := atan(q)
+	if signRe > 0 {
+		return a, nil
+	}
+	if signIm > 0 {
+		return a.Add(Pi), nil
+	}
+	return a.Sub(Pi), nil
+}
+
+// Exp returns the Complex raised to the power a.
+//
+// A real number raised to a rational power follows Real.Exp, so (-8)^(1/3) is -2 and (-8)^(1/2) is not defined.
+// Integer powers are computed exactly.
+// Other powers use the principal value exp(a ln(z)), like i^i = exp(-pi/2).
+func (z *Complex) Exp(a *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok {
+		if e, ok := a.Real(); ok && e.IsRational() {
+			res, err := r.Exp(e)
+			if err != nil {
+				return nil, err
+			}
+			return RealToComplex(res), nil
+		}
+	}
+	if n, ok := a.Fraction(); ok && n.IsInt() {
+		i, _ := n.Int()
+		if !i.IsInt64() {
+			return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("exponent %s is too big", i))
+		}
+		return z.intExp(i.Int64())
+	}
+	if z.IsNull() {
+		if a.re.Sign() > 0 {
+			return NullComplex, nil
+		}
+		return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("0^(%s) is not defined", a))
+	}
+	l, err := LnComplex(z)
+	if err != nil {
+		return nil, err
+	}
+	return ExpComplex(a.Mul(l))
+}
+
+// intExp returns the Complex raised to the integer power n
+func (z *Complex) intExp(n int64) (*Complex, error) {
+	base := z
+	if n < 0 {
+		inv, err := z.Inv()
+		if err != nil {
+			return nil, err
+		}
+		base = inv
+		n = -n
+	}
+	res := OneComplex
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			res = res.Mul(base)
+		}
+		base = base.Mul(base)
+	}
+	return res, nil
+}
+
+// Approx returns the decimal representation of the Complex, in the form a + bi.
+// Each part has the given number of digits after the decimal point.
+func (z *Complex) Approx(precision int) string {
+	return z.ApproxWith(precision, Rounding{})
+}
+
+// ApproxWith returns the decimal representation of the Complex where each part is rounded with r
+func (z *Complex) ApproxWith(precision int, r Rounding) string {
+	if z.IsReal() {
+		return z.re.ApproxWith(precision, r)
+	}
+	im := func(x *Real) string {
+		s := x.ApproxWith(precision, r)
+		switch s {
+		case "1":
+			return "i"
+		case "-1":
+			return "-i"
+		}
+		return s + "i"
+	}
+	if z.re.IsNull() {
+		return im(z.im)
+	}
+	if z.im.Sign() < 0 {
+		return z.re.ApproxWith(precision, r) + " - " + im(z.im.Neg())
+	}
+	return z.re.ApproxWith(precision, r) + " + " + im(z.im)
+}
+
+// CanBeRepresentedExactly returns true if both parts can be exactly represented with the given precision
+func (z *Complex) CanBeRepresentedExactly(precision int) bool {
+	return z.re.CanBeRepresentedExactly(precision) && z.im.CanBeRepresentedExactly(precision)
+}
+
+// String returns the exact representation of the Complex, in the form a + bi.
+// The imaginary part is surrounded by parenthesis if it is not a simple product, like (1/2)i or (1 + pi)i, and it is
+// separated from i by * if it ends with a letter, like pi*i.
+func (z *Complex) String() string {
+	if z.IsReal() {
+		return z.re.String()
+	}
+	im := func(r *Real) string {
+		s := r.String()
+		switch {
+		case s == "1":
+			return "i"
+		case s == "-1":
+			return "-i"
+		case strings.ContainsAny(s, " /"):
+			return "(" + s + ")i"
+		case unicode.IsLetter(rune(s[len(s)-1])):
+			// pi*i and not pii
+			return s + "*i"
+		}
+		return s + "i"
+	}
+	if z.re.IsNull() {
+		return im(z.im)
+	}
+	if z.im.Sign() < 0 {
+		return z.re.String() + " - " + im(z.im.Neg())
+	}
+	return z.re.String() + " + " + im(z.im)
+}
+
+// LaTeX returns the LaTeX representation of the Complex, like 1 + \sqrt{2}i
+func (z *Complex) LaTeX() string {
+	if z.IsReal() {
+		return z.re.LaTeX()
+	}
+	im := func(r *Real) string {
+		s := r.LaTeX()
+		switch {
+		case s == "1":
+			return "i"
+		case s == "-1":
+			return "-i"
+		case strings.Contains(s, " "):
+			return `\left(` + s + `\right)i`
+		}
+		return s + "i"
+	}
+	if z.re.IsNull() {
+		return im(z.im)
+	}
+	if z.im.Sign() < 0 {
+		return z.re.LaTeX() + " - " + im(z.im.Neg())
+	}
+	return z.re.LaTeX() + " + " + im(z.im)
+}
+
+// SqrtComplex returns the principal square root of z, so sqrt(-4) is 2i
+func SqrtComplex(z *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok {
+		if r.Sign() >= 0 {
+			res, err := Sqrt(r)
+			if err != nil {
+				return nil, err
+			}
+			return RealToComplex(res), nil
+		}
+		res, err := Sqrt(r.Neg())
+		if err != nil {
+			return nil, err
+		}
+		return NewComplex(NullReal, res), nil
+	}
+	// sqrt(a + bi) = sqrt((|z| + a)/2) + sign(b) sqrt((|z| - a)/2) i
+	m := z.Abs()
+	re, err := halfSqrt(m.Add(z.re))
+	if err != nil {
+		return nil, err
+	}
+	im, err := halfSqrt(m.Sub(z.re))
+	if err != nil {
+		return nil, err
+	}
+	if z.im.Sign() < 0 {
+		im = im.Neg()
+	}
+	return NewComplex(re, im), nil
+}
+
+// halfSqrt returns sqrt(r/2)
+func halfSqrt(r *Real) (*Real, error) {
+	h, err := r.Div(IntToReal(2))
+	if err != nil {
+		return nil, err
+	}
+	return Sqrt(h)
+}
+
+// LnComplex returns the principal value of the natural logarithm of z, so ln(-1) is pi*i.
+// Returns ErrIllegalOperation if z is null.
+func LnComplex(z *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok && r.Sign() > 0 {
+		res, err := Ln(r)
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(res), nil
+	}
+	if z.IsNull() {
+		return nil, genErrNotInDomain("ln", z.re)
+	}
+	arg, err := z.Arg()
+	if err != nil {
+		return nil, err
+	}
+	if z.IsReal() {
+		// ln(-x) = ln(x) + pi*i
+		re, err := Ln(z.re.Neg())
+		if err != nil {
+			return nil, err
+		}
+		return NewComplex(re, arg), nil
+	}
+	// ln|z| = ln(a^2 + b^2)/2
+	l, err := Ln(z.norm())
+	if err != nil {
+		return nil, err
+	}
+	re, err := l.Div(IntToReal(2))
+	if err != nil {
+		return nil, err
+	}
+	return NewComplex(re, arg), nil
+}
+
+// ExpComplex returns e^z = e^a (cos(b) + i sin(b))
+func ExpComplex(z *Complex) (*Complex, error) {
+	ea, err := Exp(z.re)
+	if err != nil {
+		return nil, err
+	}
+	if z.IsReal() {
+		return RealToComplex(ea), nil
+	}
+	c, err := Cos(z.im)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Sin(z.im)
+	if err != nil {
+		return nil, err
+	}
+	return NewComplex(ea.Mul(c), ea.Mul(s)), nil
+}
+
+// coshSinh returns cosh(r) and sinh(r)
+func coshSinh(r *Real) (*Real, *Real, error) {
+	e, err := Exp(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	inv, err := e.Inv()
+	if err != nil {
+		return nil, nil, err
+	}
+	two := IntToReal(2)
+	cosh, err := e.Add(inv).Div(two)
+	if err != nil {
+		return nil, nil, err
+	}
+	sinh, err := e.Sub(inv).Div(two)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cosh, sinh, nil
+}
+
+// sinCos returns the sine and the cosine of r
+func sinCos(r *Real) (*Real, *Real, error) {
+	s, err := Sin(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := Cos(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, c, nil
+}
+
+// SinComplex returns sin(a + bi) = sin(a)cosh(b) + i cos(a)sinh(b)
+func SinComplex(z *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok {
+		res, err := Sin(r)
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(res), nil
+	}
+	s, c, err := sinCos(z.re)
+	if err != nil {
+		return nil, err
+	}
+	ch, sh, err := coshSinh(z.im)
+	if err != nil {
+		return nil, err
+	}
+	return NewComplex(s.Mul(ch), c.Mul(sh)), nil
+}
+
+// CosComplex returns cos(a + bi) = cos(a)cosh(b) - i sin(a)sinh(b)
+func CosComplex(z *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok {
+		res, err := Cos(r)
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(res), nil
+	}
+	s, c, err := sinCos(z.re)
+	if err != nil {
+		return nil, err
+	}
+	ch, sh, err := coshSinh(z.im)
+	if err != nil {
+		return nil, err
+	}
+	return NewComplex(c.Mul(ch), s.Mul(sh).Neg()), nil
+}
+
+// TanComplex returns tan(a + bi) = (sin(2a) + i sinh(2b))/(cos(2a) + cosh(2b))
+func TanComplex(z *Complex) (*Complex, error) {
+	if r, ok := z.Real(); ok {
+		res, err := Tan(r)
+		if err != nil {
+			return nil, err
+		}
+		return RealToComplex(res), nil
+	}
+	two := IntToReal(2)
+	s, c, err := sinCos(z.re.Mul(two))
+	if err != nil {
+		return nil, err
+	}
+	ch, sh, err := coshSinh(z.im.Mul(two))
+	if err != nil {
+		return nil, err
+	}
+	return NewComplex(s, sh).Div(RealToComplex(c.Add(ch)))
+}
@@ -0,0 +1,108 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestComplex_String(t *testing.T) {
+	genericTest := func(z *Complex, expected string) {
+		if z.String() != expected {
+			t.Errorf("got %s; want %s", z, expected)
+		}
+	}
+	sqrt2, err := Sqrt(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(I, "i")
+	genericTest(I.Neg(), "-i")
+	genericTest(NewComplex(IntToReal(3), IntToReal(4)), "3 + 4i")
+	genericTest(NewComplex(IntToReal(3), IntToReal(-4)), "3 - 4i")
+	genericTest(NewComplex(NullReal, FractionToReal(NewFraction(1, 2))), "(1/2)i")
+	genericTest(NewComplex(OneReal, Pi), "1 + pi*i")
+	genericTest(NewComplex(NullReal, sqrt2), "sqrt(2)i")
+	genericTest(NewComplex(IntToReal(2), NullReal), "2")
+}
+
+func TestComplex_LaTeX(t *testing.T) {
+	genericTest := func(z *Complex, expected string) {
+		if z.LaTeX() != expected {
+			t.Errorf("got %s; want %s", z.LaTeX(), expected)
+		}
+	}
+	genericTest(NewComplex(IntToReal(3), IntToReal(-4)), `3 - 4i`)
+	genericTest(NewComplex(NullReal, FractionToReal(NewFraction(1, 2))), `\frac{1}{2}i`)
+	genericTest(NewComplex(OneReal, Pi.Add(OneReal)), `1 + \left(1 + \pi\right)i`)
+}
+
+func TestComplex_Arithmetic(t *testing.T) {
+	genericTest := func(z *Complex, expected string) {
+		if z.String() != expected {
+			t.Errorf("got %s; want %s", z, expected)
+		}
+	}
+	a := NewComplex(OneReal, IntToReal(2))
+	b := NewComplex(IntToReal(3), IntToReal(-1))
+	genericTest(a.Add(b), "4 + i")
+	genericTest(a.Sub(b), "-2 + 3i")
+	genericTest(a.Mul(b), "5 + 5i")
+	genericTest(I.Mul(I), "-1")
+	res, err := OneComplex.Div(NewComplex(OneReal, OneReal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(res, "1/2 - (1/2)i")
+	res, err = NewComplex(OneReal, OneReal).Exp(IntToComplex(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(res, "32i")
+	res, err = I.Exp(I)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(res, "exp(-pi/2)")
+	if _, err = I.Div(NullComplex); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+}
+
+func TestComplex_Functions(t *testing.T) {
+	genericTest := func(fn func(*Complex) (*Complex, error), z *Complex, expected string) {
+		res, err := fn(z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.String() != expected {
+			t.Errorf("got %s; want %s", res, expected)
+		}
+	}
+	genericTest(SqrtComplex, IntToComplex(-4), "2i")
+	genericTest(SqrtComplex, NewComplex(IntToReal(3), IntToReal(4)), "2 + i")
+	genericTest(SqrtComplex, NewComplex(NullReal, IntToReal(2)), "1 + i")
+	genericTest(LnComplex, IntToComplex(-1), "pi*i")
+	genericTest(LnComplex, IntToComplex(-2), "ln(2) + pi*i")
+	genericTest(LnComplex, NewComplex(OneReal, OneReal), "ln(2)/2 + (pi/4)i")
+	genericTest(ExpComplex, NewComplex(NullReal, Pi), "-1")
+
+	genericApprox := func(fn func(*Complex) (*Complex, error), z *Complex, precision int, expected string) {
+		res, err := fn(z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Approx(precision); got != expected {
+			t.Errorf("%s: got %s; want %s", res, got, expected)
+		}
+	}
+	one := NewComplex(OneReal, OneReal)
+	genericApprox(ExpComplex, one, 20, "1.46869393991588515714 + 2.28735528717884239121i")
+	genericApprox(SinComplex, one, 20, "1.29845758141597729483 + 0.63496391478473610826i")
+	genericApprox(CosComplex, one, 20, "0.83373002513114904888 - 0.98889770576286509638i")
+	genericApprox(TanComplex, one, 20, "0.27175258531951171653 + 1.08392332733869454348i")
+	genericApprox(LnComplex, NewComplex(IntToReal(3), IntToReal(4)), 20, "1.6094379124341003746 + 0.92729521800161223243i")
+
+	if _, err := LnComplex(NullComplex); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+}
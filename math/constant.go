@@ -0,0 +1,103 @@
+package math
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	// Pi is the ratio of a circle's circumference to its diameter
+	Pi = constantToReal(&constant{name: "pi", latex: `\pi`, compute: computePi})
+	// E is Euler's number, the base of natural logarithms
+	E = constantToReal(&constant{name: "e", latex: "e", compute: computeE})
+	// Phi is the golden ratio
+	Phi = constantToReal(&constant{name: "phi", latex: `\phi`, compute: computePhi})
+)
+
+// constant is an irrational constant, like pi or e.
+// Its approximation is cached: it is computed again only when a higher precision is required.
+type constant struct {
+	name    string
+	latex   string
+	compute func(prec uint) *big.Float
+
+	mu     sync.Mutex
+	cached *big.Float
+}
+
+func constantToReal(c *constant) *Real {
+	return newReal(&term{OneFraction, []*factor{{c, 1}}})
+}
+
+func (c *constant) key() string {
+	return c.name
+}
+
+func (c *constant) String() string {
+	return c.name
+}
+
+func (c *constant) LaTeX() string {
+	return c.latex
+}
+
+func (c *constant) approx(prec uint) *big.Float {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached == nil || c.cached.Prec() < prec {
+		c.cached = c.compute(prec)
+	}
+	return new(big.Float).SetPrec(prec).Set(c.cached)
+}
+
+// computePi computes pi with Machin's formula: pi = 16 atan(1/5) - 4 atan(1/239)
+func computePi(prec uint) *big.Float {
+	wp := prec + guardBits
+	a := atanInv(5, wp)
+	a.Mul(a, big.NewFloat(16))
+	b := atanInv(239, wp)
+	b.Mul(b, big.NewFloat(4))
+	return a.Sub(a, b).SetPrec(prec)
+}
+
+// atanInv computes atan(1/x) with the series sum((-1)^k / ((2k+1) x^(2k+1)))
+func atanInv(x int64, prec uint) *big.Float {
+	sum := new(big.Float).SetPrec(prec)
+	// pow is 1/x^(2k+1)
+	pow := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(float64(x)))
+	x2 := new(big.Float).SetPrec(prec).SetInt64(x * x)
+	t := new(big.Float).SetPrec(prec)
+	for k := int64(0); ; k++ {
+		t.Quo(pow, new(big.Float).SetInt64(2*k+1))
+		if t.Sign() == 0 || t.MantExp(nil) < -int(prec) {
+			return sum
+		}
+		if k%2 == 0 {
+			sum.Add(sum, t)
+		} else {
+			sum.Sub(sum, t)
+		}
+		pow.Quo(pow, x2)
+	}
+}
+
+// computeE computes e with the series sum(1/k!)
+func computeE(prec uint) *big.Float {
+	wp := prec + guardBits
+	sum := new(big.Float).SetPrec(wp).SetInt64(1)
+	t := new(big.Float).SetPrec(wp).SetInt64(1)
+	for k := int64(1); t.MantExp(nil) >= -int(wp); k++ {
+		t.Quo(t, new(big.Float).SetInt64(k))
+		sum.Add(sum, t)
+	}
+	return sum.SetPrec(prec)
+}
+
+// computePhi computes phi = (1 + sqrt(5))/2
+func computePhi(prec uint) *big.Float {
+	wp := prec + guardBits
+	s := new(big.Float).SetPrec(wp).SetInt64(5)
+	s.Sqrt(s)
+	s.Add(s, big.NewFloat(1))
+	return s.Quo(s, big.NewFloat(2)).SetPrec(prec)
+}
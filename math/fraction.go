@@ -17,7 +17,6 @@ var (
 	NullBigInt   = big.NewInt(0)
 	OneFraction  = IntToFraction(1)
 	NullFraction = IntToFraction(0)
-	Pi           *Fraction
 
 	// ErrFractionNotInt is thrown when a non-integer Fraction is converted into an int
 	ErrFractionNotInt = errors.New("fraction is not an int")
@@ -25,16 +24,12 @@ var (
 	ErrIllegalOperation = errors.New("illegal operation")
 	// ErrUnsupportedOperation is thrown when an unsupported operation is performed
 	ErrUnsupportedOperation = errors.New("unsupported operation")
+	// ErrInvalidNumber is thrown when a number cannot be converted into a Fraction
+	ErrInvalidNumber = errors.New("invalid number")
+	// ErrIrrationalResult is thrown when the result of an operation cannot be represented by a Fraction
+	ErrIrrationalResult = errors.New("irrational result")
 )
 
-func init() {
-	var err error
-	Pi, err = FloatToFraction(math.Pi)
-	if err != nil {
-		panic(err)
-	}
-}
-
 func NewFraction(a, b int64) *Fraction {
 	return &Fraction{big.NewRat(a, b)}
 }
@@ -44,18 +39,60 @@ func IntToFraction(n int64) *Fraction {
 	return NewFraction(n, 1)
 }
 
-// FloatToFraction converts a float64 into a Fraction
+// FloatToFraction converts a float64 into a Fraction.
+// The Fraction is the shortest decimal representation of the float64, e.g. 0.1 is converted into 1/10.
 func FloatToFraction(f float64) (*Fraction, error) {
-	if f == float64(int64(f)) {
-		return IntToFraction(int64(f)), nil
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%f cannot be converted into a Fraction", f))
 	}
-	s := strconv.FormatFloat(f, 'f', -1, 64)
-	sp := strings.Split(s, ".")
-	i, err := strconv.ParseInt(sp[0]+sp[1], 10, 64)
-	if err != nil {
-		return nil, err
+	return StringToFraction(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// StringToFraction converts a decimal number into a Fraction without losing any digit.
+// The scientific notation is supported, e.g. 6.02e23 or 1.5E-3.
+func StringToFraction(s string) (*Fraction, error) {
+	if !IsDecimal(s) {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%s is not a decimal number", s))
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%s cannot be converted into a Fraction", s))
+	}
+	return &Fraction{r}, nil
+}
+
+// IsDecimal returns true if s is a decimal number, like 12, -1.5, .5 or 6.02e23
+func IsDecimal(s string) bool {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(s), "e")
+	if hasExponent {
+		if strings.HasPrefix(exponent, "-") || strings.HasPrefix(exponent, "+") {
+			exponent = exponent[1:]
+		}
+		if !onlyDigits(exponent) {
+			return false
+		}
 	}
-	return NewFraction(i, int64(math.Pow(10, float64(len(sp[1]))))), nil
+	integer, decimal, _ := strings.Cut(mantissa, ".")
+	if integer == "" && decimal == "" {
+		return false
+	}
+	return (integer == "" || onlyDigits(integer)) && (decimal == "" || onlyDigits(decimal))
+}
+
+// onlyDigits returns true if s is not empty and only contains digits
+func onlyDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 func (f Fraction) String() string {
@@ -67,15 +104,7 @@ func (f Fraction) Is(a *Fraction) bool {
 }
 
 func (f Fraction) Approx(precision int) string {
-	if f.IsInt() {
-		n, _ := f.Int()
-		return fmt.Sprintf("%d", n)
-	}
-	s := strings.TrimSuffix(f.Rat.FloatString(precision), "0")
-	for strings.HasSuffix(s, "0") {
-		s = strings.TrimSuffix(s, "0")
-	}
-	return s
+	return f.ApproxWith(precision, Rounding{})
 }
 
 func (f Fraction) CanBeRepresentedExactly(precision int) bool {
@@ -192,34 +221,109 @@ func (f Fraction) Float() (float64, bool) {
 	return f.Float64()
 }
 
-// Exp the Fraction by another
+// Mod returns the remainder of the floored division of the Fraction by a, f - a*floor(f/a).
+// The remainder has the sign of a, so -7 mod 3 is 2, 7 mod -3 is -2 and 7/2 mod 1 is 1/2.
+// Returns ErrIllegalOperation if a is null.
+func (f Fraction) Mod(a *Fraction) (*Fraction, error) {
+	q, err := f.Div(a)
+	if err != nil {
+		return nil, errors.Join(err, fmt.Errorf("%s mod 0 is not defined", f.String()))
+	}
+	return f.Sub(a.Mul(q.floor())), nil
+}
+
+// floor returns the biggest integer smaller than or equal to the Fraction
+func (f Fraction) floor() *Fraction {
+	// the denominator is positive, so the Euclidean division rounds toward -inf
+	n := new(big.Int).Div(f.Num(), f.Denom())
+	return &Fraction{new(big.Rat).SetInt(n)}
+}
+
+// Exp the Fraction by another.
+//
+// Rational exponents are supported when the result is rational, like 8^(2/3) = 4.
+// Returns ErrIllegalOperation if the result is not defined (like 0^-1 or (-8)^(1/2)) and ErrIrrationalResult if it
+// is not rational (like 2^(1/2)): use Real.Exp to keep it exact.
 func (f Fraction) Exp(a *Fraction) (*Fraction, error) {
-	if a.IsInt() {
-		n, _ := a.Int()
-		fl, _ := f.Float()
-		if fl == 0 {
-			if n.Cmp(NullBigInt) == 0 {
-				return OneFraction, nil
-			}
+	if f.Sign() == 0 {
+		switch a.Sign() {
+		case 0:
+			return OneFraction, nil
+		case 1:
 			return NullFraction, nil
 		}
-		c := f.Copy()
-		c.Num().Exp(f.Num(), n, nil)
-		c.Denom().Exp(f.Denom(), n, nil)
-		return c, nil
-	}
-	//afl, _ := a.Float()
-	//nf, err := FloatToFraction(math.Pow(float64(f.Num().P), afl))
-	//if err != nil {
-	//	return NullFraction, errors.Join(err, errors.New("cannot convert numerator^a into a Fraction"))
-	//}
-	//nff, err := FloatToFraction(math.Pow(float64(f.Denominator), afl))
-	//if err != nil {
-	//	return NullFraction, errors.Join(err, errors.New("cannot convert denominator^a into a Fraction"))
-	//}
-	//pf, err := nf.Div(nff)
-	//if err != nil {
-	//	return NullFraction, err
-	//}
-	return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("Fraction.Exp(%s) is not supported because it's not an int", a))
+		return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("0^%s is not defined", a))
+	}
+	n, q := a.Num(), a.Denom()
+	if !q.IsInt64() {
+		return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("root of index %s is too big", q))
+	}
+	base := f.Copy()
+	if q.Cmp(big.NewInt(1)) != 0 {
+		index := q.Int64()
+		if f.Sign() < 0 && index%2 == 0 {
+			return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("(%s)^(%s) is not defined", f.String(), a))
+		}
+		num, okNum := intRoot(new(big.Int).Abs(f.Num()), index)
+		den, okDen := intRoot(f.Denom(), index)
+		if !okNum || !okDen {
+			return nil, errors.Join(ErrIrrationalResult, fmt.Errorf("(%s)^(%s) is not rational", f.String(), a))
+		}
+		if f.Sign() < 0 {
+			num.Neg(num)
+		}
+		base.Rat.SetFrac(num, den)
+	}
+	if n.Sign() < 0 {
+		base.Rat.Inv(base.Rat)
+	}
+	e := new(big.Int).Abs(n)
+	num := new(big.Int).Exp(base.Num(), e, nil)
+	den := new(big.Int).Exp(base.Denom(), e, nil)
+	base.Rat.SetFrac(num, den)
+	return base, nil
+}
+
+// GCD returns the greatest common divisor of the Fraction and a, which must be integers.
+// The result is positive, or null if both are null.
+// Returns ErrFractionNotInt if a Fraction is not an integer.
+func (f Fraction) GCD(a *Fraction) (*Fraction, error) {
+	x, y, err := f.intPair(a, "gcd")
+	if err != nil {
+		return nil, err
+	}
+	x.Abs(x)
+	y.Abs(y)
+	return &Fraction{new(big.Rat).SetInt(new(big.Int).GCD(nil, nil, x, y))}, nil
+}
+
+// LCM returns the least common multiple of the Fraction and a, which must be integers.
+// The result is positive, or null if one of them is null.
+// Returns ErrFractionNotInt if a Fraction is not an integer.
+func (f Fraction) LCM(a *Fraction) (*Fraction, error) {
+	g, err := f.GCD(a)
+	if err != nil {
+		return nil, err
+	}
+	if g.Sign() == 0 {
+		return NullFraction, nil
+	}
+	res, err := f.Mul(a).Div(g)
+	if err != nil {
+		return nil, err
+	}
+	return &Fraction{res.Abs(res.Rat)}, nil
+}
+
+// intPair returns the Fraction and a as integers, the name of the operation being used in the error
+func (f Fraction) intPair(a *Fraction, name string) (*big.Int, *big.Int, error) {
+	x, err := f.Int()
+	if err != nil {
+		return nil, nil, errors.Join(err, fmt.Errorf("%s(%s, %s) is only defined for integers", name, f.String(), a))
+	}
+	y, err := a.Int()
+	if err != nil {
+		return nil, nil, errors.Join(err, fmt.Errorf("%s(%s, %s) is only defined for integers", name, f.String(), a))
+	}
+	return x, y, nil
 }
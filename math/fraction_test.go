@@ -160,6 +160,97 @@ func TestFraction_Inv(t *testing.T) {
 	}
 }
 
+func TestFraction_Mod(t *testing.T) {
+	genericTest := func(f, a, expected *Fraction) {
+		res, err := f.Mod(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Is(expected) {
+			t.Errorf("%s mod %s: got %s; want %s", f, a, res, expected)
+		}
+	}
+	genericTest(IntToFraction(7), IntToFraction(3), OneFraction)
+	genericTest(IntToFraction(-7), IntToFraction(3), IntToFraction(2))
+	genericTest(IntToFraction(7), IntToFraction(-3), IntToFraction(-2))
+	genericTest(IntToFraction(-7), IntToFraction(-3), IntToFraction(-1))
+	genericTest(NewFraction(7, 2), OneFraction, NewFraction(1, 2))
+	genericTest(NewFraction(11, 2), IntToFraction(2), NewFraction(3, 2))
+	genericTest(NewFraction(5, 6), NewFraction(1, 4), NewFraction(1, 12))
+
+	t.Log("testing modulo by null Fraction")
+	_, err := OneFraction.Mod(NullFraction)
+	if !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %s", err)
+	}
+}
+
+func TestFraction_GCD(t *testing.T) {
+	genericTest := func(f, a, gcd, lcm *Fraction) {
+		res, err := f.GCD(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Is(gcd) {
+			t.Errorf("gcd(%s, %s): got %s; want %s", f, a, res, gcd)
+		}
+		res, err = f.LCM(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Is(lcm) {
+			t.Errorf("lcm(%s, %s): got %s; want %s", f, a, res, lcm)
+		}
+	}
+	genericTest(IntToFraction(12), IntToFraction(18), IntToFraction(6), IntToFraction(36))
+	genericTest(IntToFraction(-4), IntToFraction(6), IntToFraction(2), IntToFraction(12))
+	genericTest(IntToFraction(7), NullFraction, IntToFraction(7), NullFraction)
+	genericTest(NullFraction, NullFraction, NullFraction, NullFraction)
+
+	t.Log("testing gcd of a non-integer Fraction")
+	_, err := NewFraction(1, 2).GCD(OneFraction)
+	if !errors.Is(err, ErrFractionNotInt) {
+		t.Errorf("expected fraction not int error, not %s", err)
+	}
+}
+
+func TestFraction_Exp(t *testing.T) {
+	genericTest := func(f, a, expected *Fraction) {
+		res, err := f.Exp(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Is(expected) {
+			t.Errorf("(%s)^(%s): got %s; want %s", f, a, res, expected)
+		}
+	}
+	t.Log("testing integer exponents")
+	genericTest(NewFraction(2, 3), IntToFraction(3), NewFraction(8, 27))
+	genericTest(IntToFraction(2), IntToFraction(-3), NewFraction(1, 8))
+	genericTest(NewFraction(-2, 3), IntToFraction(-2), NewFraction(9, 4))
+	genericTest(NullFraction, NullFraction, OneFraction)
+
+	t.Log("testing rational exponents")
+	genericTest(IntToFraction(8), NewFraction(2, 3), IntToFraction(4))
+	genericTest(IntToFraction(4), NewFraction(1, 2), IntToFraction(2))
+	genericTest(NewFraction(27, 8), NewFraction(-2, 3), NewFraction(4, 9))
+	genericTest(IntToFraction(-8), NewFraction(1, 3), IntToFraction(-2))
+
+	t.Log("testing illegal exponents")
+	_, err := IntToFraction(-8).Exp(NewFraction(1, 2))
+	if !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %s", err)
+	}
+	_, err = NullFraction.Exp(IntToFraction(-1))
+	if !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %s", err)
+	}
+	_, err = IntToFraction(2).Exp(NewFraction(1, 2))
+	if !errors.Is(err, ErrIrrationalResult) {
+		t.Errorf("expected irrational result error, not %s", err)
+	}
+}
+
 func TestFraction_Approx(t *testing.T) {
 	expected := "3.1415"
 	f := NewFraction(6283, 2000)
@@ -196,6 +287,18 @@ func TestFraction_Approx(t *testing.T) {
 	if res != expected {
 		t.Errorf("got %s; want %s", res, expected)
 	}
+
+	t.Log("testing rounding to an integer")
+	res = NewFraction(19, 2).Approx(0)
+	expected = "10"
+	if res != expected {
+		t.Errorf("got %s; want %s", res, expected)
+	}
+	res = NewFraction(-1, 1000).Approx(2)
+	expected = "0"
+	if res != expected {
+		t.Errorf("got %s; want %s", res, expected)
+	}
 }
 
 func TestFraction_CanBeRepresentedExactly(t *testing.T) {
@@ -222,3 +325,47 @@ func TestFraction_CanBeRepresentedExactly(t *testing.T) {
 		t.Errorf("5/1 should be exact no matter the precision")
 	}
 }
+
+func TestStringToFraction(t *testing.T) {
+	genericTest := func(s string, expected string) {
+		f, err := StringToFraction(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.String() != expected {
+			t.Errorf("%s: got %s; want %s", s, f, expected)
+		}
+	}
+	genericTest("0.1", "1/10")
+	genericTest(".5", "1/2")
+	genericTest("-1.5", "-3/2")
+	genericTest("12345678901234567890", "12345678901234567890")
+	genericTest("1.000000000000000000001", "1000000000000000000001/1000000000000000000000")
+	genericTest("6.02e23", "602000000000000000000000")
+	genericTest("1.5E-3", "3/2000")
+
+	t.Log("testing invalid numbers")
+	for _, s := range []string{"", ".", "1/2", "inf", "NaN", "0x10", "1e", "1.2.3"} {
+		_, err := StringToFraction(s)
+		if !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("%s: expected invalid number error, not %v", s, err)
+		}
+	}
+}
+
+func TestFloatToFraction(t *testing.T) {
+	f, err := FloatToFraction(0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := NewFraction(1, 10); !f.Is(expected) {
+		t.Errorf("got %s; want %s", f, expected)
+	}
+	f, err = FloatToFraction(1e20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "100000000000000000000"; f.String() != expected {
+		t.Errorf("got %s; want %s", f, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package math
+
+// Sinh returns the hyperbolic sine of r.
+// It is exact for 0 and for the logarithms of the rationals.
+// Returns ErrUnsupportedOperation if the result is too large to be approximated, like sinh(10^6).
+func Sinh(r *Real) (*Real, error) {
+	if r.IsNull() {
+		return NullReal, nil
+	}
+	if x, ok := r.inverseOf("asinh"); ok {
+		return x, nil
+	}
+	// sinh(ln(x)) = (x - 1/x)/2
+	if x, ok := r.inverseOf("ln"); ok && x.IsRational() {
+		inv, _ := x.Inv()
+		return x.Sub(inv).Div(IntToReal(2))
+	}
+	if expTooLarge(r) || expTooLarge(r.Neg()) {
+		return nil, genErrTooLarge("sinh(" + r.String() + ")")
+	}
+	return applicationToReal("sinh", `\sinh\left(%s\right)`, r, sinhFloat), nil
+}
+
+// Cosh returns the hyperbolic cosine of r.
+// It is exact for 0 and for the logarithms of the rationals.
+// Returns ErrUnsupportedOperation if the result is too large to be approximated, like cosh(10^6).
+func Cosh(r *Real) (*Real, error) {
+	if r.IsNull() {
+		return OneReal, nil
+	}
+	if x, ok := r.inverseOf("acosh"); ok {
+		return x, nil
+	}
+	// cosh(ln(x)) = (x + 1/x)/2
+	if x, ok := r.inverseOf("ln"); ok && x.IsRational() {
+		inv, _ := x.Inv()
+		return x.Add(inv).Div(IntToReal(2))
+	}
+	if expTooLarge(r) || expTooLarge(r.Neg()) {
+		return nil, genErrTooLarge("cosh(" + r.String() + ")")
+	}
+	return applicationToReal("cosh", `\cosh\left(%s\right)`, r, coshFloat), nil
+}
+
+// Tanh returns the hyperbolic tangent of r.
+// It is exact for 0 and for the logarithms of the rationals.
+func Tanh(r *Real) (*Real, error) {
+	if r.IsNull() {
+		return NullReal, nil
+	}
+	if x, ok := r.inverseOf("atanh"); ok {
+		return x, nil
+	}
+	// tanh(ln(x)) = (x^2 - 1)/(x^2 + 1)
+	if x, ok := r.inverseOf("ln"); ok && x.IsRational() {
+		x2 := x.Mul(x)
+		return x2.Sub(OneReal).Div(x2.Add(OneReal))
+	}
+	return applicationToReal("tanh", `\tanh\left(%s\right)`, r, tanhFloat), nil
+}
+
+// Asinh returns the inverse hyperbolic sine of r
+func Asinh(r *Real) (*Real, error) {
+	if r.IsNull() {
+		return NullReal, nil
+	}
+	if x, ok := r.inverseOf("sinh"); ok {
+		return x, nil
+	}
+	return applicationToReal("asinh", `\operatorname{arsinh}\left(%s\right)`, r, asinhFloat), nil
+}
+
+// Acosh returns the inverse hyperbolic cosine of r, which is positive.
+// Returns ErrIllegalOperation if r < 1.
+func Acosh(r *Real) (*Real, error) {
+	if r.SmallerThan(OneReal) {
+		return nil, genErrNotInDomain("acosh", r)
+	}
+	if r.Is(OneReal) {
+		return NullReal, nil
+	}
+	return applicationToReal("acosh", `\operatorname{arcosh}\left(%s\right)`, r, acoshFloat), nil
+}
+
+// Atanh returns the inverse hyperbolic tangent of r.
+// Returns ErrIllegalOperation if r is not in ]-1 ; 1[.
+func Atanh(r *Real) (*Real, error) {
+	if r.GreaterOrEqualThan(OneReal) || r.SmallerOrEqualThan(OneReal.Neg()) {
+		return nil, genErrNotInDomain("atanh", r)
+	}
+	if r.IsNull() {
+		return NullReal, nil
+	}
+	if x, ok := r.inverseOf("tanh"); ok {
+		return x, nil
+	}
+	return applicationToReal("atanh", `\operatorname{artanh}\left(%s\right)`, r, atanhFloat), nil
+}
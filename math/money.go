@@ -0,0 +1,222 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCurrencyMismatch is thrown when an operation mixes amounts which cannot be combined, like 1 EUR * 1 EUR
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrUnknownCurrency is thrown when a currency is not in the RateTable used to convert an amount
+	ErrUnknownCurrency = errors.New("unknown currency")
+)
+
+// currencyCodes are the active ISO 4217 currency codes
+var currencyCodes = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD CAD CDF
+		CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF
+		GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD
+		LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP
+		PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND
+		TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR
+		XOF XPD XPF XPT XSU XUA YER ZAR ZMW ZWG ZWL`) {
+		currencyCodes[code] = true
+	}
+}
+
+// IsCurrencyCode returns true if s is an ISO 4217 currency code, like EUR
+func IsCurrencyCode(s string) bool {
+	return currencyCodes[s]
+}
+
+// Money is an exact amount of a currency, like 12.5 EUR
+type Money struct {
+	Amount *Fraction
+	// Currency is the ISO 4217 code of the currency (empty if the Money is a plain number)
+	Currency string
+}
+
+// NewMoney returns the amount in the currency
+func NewMoney(amount *Fraction, currency string) *Money {
+	return &Money{Amount: amount, Currency: currency}
+}
+
+// IsNumber returns true if the Money is a plain number without currency
+func (m *Money) IsNumber() bool {
+	return m.Currency == ""
+}
+
+func (m *Money) checkCurrency(a *Money, op string) error {
+	if m.Currency != a.Currency {
+		return errors.Join(ErrCurrencyMismatch, fmt.Errorf("%s %s %s: the amounts must have the same currency", m, op, a))
+	}
+	return nil
+}
+
+// Add returns the sum of the amounts.
+// Returns ErrCurrencyMismatch if they do not have the same currency: convert them with a RateTable before.
+func (m *Money) Add(a *Money) (*Money, error) {
+	if err := m.checkCurrency(a, "+"); err != nil {
+		return nil, err
+	}
+	return NewMoney(m.Amount.Add(a.Amount), m.Currency), nil
+}
+
+// Sub returns the difference of the amounts.
+// Returns ErrCurrencyMismatch if they do not have the same currency: convert them with a RateTable before.
+func (m *Money) Sub(a *Money) (*Money, error) {
+	if err := m.checkCurrency(a, "-"); err != nil {
+		return nil, err
+	}
+	return NewMoney(m.Amount.Sub(a.Amount), m.Currency), nil
+}
+
+// Cmp compares the amounts.
+// Returns -1 if m < a, 0 if m = a and +1 if m > a, and ErrCurrencyMismatch if they do not have the same currency:
+// convert them with a RateTable before.
+func (m *Money) Cmp(a *Money) (int, error) {
+	if err := m.checkCurrency(a, "compared with"); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(a.Amount.Rat), nil
+}
+
+func (m *Money) Neg() *Money {
+	return NewMoney(m.Amount.Neg(), m.Currency)
+}
+
+// Mul returns the product of the amounts.
+// Returns ErrCurrencyMismatch if both have a currency.
+func (m *Money) Mul(a *Money) (*Money, error) {
+	if !m.IsNumber() && !a.IsNumber() {
+		return nil, errors.Join(ErrCurrencyMismatch, fmt.Errorf("%s * %s: cannot multiply two amounts", m, a))
+	}
+	return NewMoney(m.Amount.Mul(a.Amount), m.Currency+a.Currency), nil
+}
+
+// Div returns m/a: an amount divided by an amount of the same currency is a plain number.
+// Returns ErrCurrencyMismatch if a has a currency which is not the one of m, and ErrIllegalOperation if a is null.
+func (m *Money) Div(a *Money) (*Money, error) {
+	currency := m.Currency
+	if !a.IsNumber() {
+		if err := m.checkCurrency(a, "/"); err != nil {
+			return nil, err
+		}
+		currency = ""
+	}
+	v, err := m.Amount.Div(a.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return NewMoney(v, currency), nil
+}
+
+// Exp returns m^a.
+// Returns ErrCurrencyMismatch if m has a currency and the errors of Fraction.Exp.
+func (m *Money) Exp(a *Fraction) (*Money, error) {
+	if !m.IsNumber() {
+		return nil, errors.Join(ErrCurrencyMismatch, fmt.Errorf("(%s)^(%s): an amount cannot be exponentiated", m, a))
+	}
+	v, err := m.Amount.Exp(a)
+	if err != nil {
+		return nil, err
+	}
+	return NewMoney(v, ""), nil
+}
+
+// withCurrency appends the currency to the representation of the amount
+func (m *Money) withCurrency(s string) string {
+	if m.IsNumber() {
+		return s
+	}
+	return s + " " + m.Currency
+}
+
+// String returns the exact Money, like 25/2 EUR
+func (m *Money) String() string {
+	return m.withCurrency(m.Amount.String())
+}
+
+// FixedString returns the Money rounded to the given number of decimals, like 12.50 EUR
+func (m *Money) FixedString(decimals int, mode RoundingMode) string {
+	return m.withCurrency(m.Amount.FixedString(decimals, mode))
+}
+
+// ApproxWith returns the Money with the given number of decimals rounded with r, like 12.5 EUR
+func (m *Money) ApproxWith(precision int, r Rounding) string {
+	return m.withCurrency(m.Amount.ApproxWith(precision, r))
+}
+
+// LaTeX returns the LaTeX representation of the Money rounded to the given number of decimals, like
+// 12.50\,\mathrm{EUR}
+func (m *Money) LaTeX(decimals int, mode RoundingMode) string {
+	s := m.Amount.FixedString(decimals, mode)
+	if m.IsNumber() {
+		return s
+	}
+	return s + `\,\mathrm{` + m.Currency + `}`
+}
+
+// RateTable contains the conversion rates of currencies relative to a base currency
+type RateTable struct {
+	// Base is the ISO 4217 code of the currency in which the rates are expressed
+	Base  string
+	rates map[string]*Fraction
+}
+
+// NewRateTable creates an empty RateTable whose rates are expressed in the base currency
+func NewRateTable(base string) *RateTable {
+	return &RateTable{Base: base, rates: map[string]*Fraction{}}
+}
+
+// Set the rate of the currency: 1 code is worth rate Base.
+// Returns ErrUnknownCurrency if code is not an ISO 4217 code and ErrIllegalOperation if the rate is not positive.
+func (t *RateTable) Set(code string, rate *Fraction) error {
+	if !IsCurrencyCode(code) {
+		return errors.Join(ErrUnknownCurrency, fmt.Errorf("%s is not an ISO 4217 currency code (excepted like EUR)", code))
+	}
+	if rate.Sign() <= 0 {
+		return errors.Join(ErrIllegalOperation, fmt.Errorf("the rate of %s must be positive (got %s)", code, rate))
+	}
+	t.rates[code] = rate
+	return nil
+}
+
+// Rate returns the value of 1 code in the base currency
+func (t *RateTable) Rate(code string) (*Fraction, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if code == t.Base {
+		return OneFraction, true
+	}
+	r, ok := t.rates[code]
+	return r, ok
+}
+
+// Convert the Money in the currency to.
+// Returns ErrUnknownCurrency if a currency is not in the RateTable and ErrCurrencyMismatch if the Money is a plain
+// number.
+func (t *RateTable) Convert(m *Money, to string) (*Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	if m.IsNumber() {
+		return nil, errors.Join(ErrCurrencyMismatch, fmt.Errorf("%s is not an amount: it cannot be converted in %s", m, to))
+	}
+	from, ok := t.Rate(m.Currency)
+	if !ok {
+		return nil, errors.Join(ErrUnknownCurrency, fmt.Errorf("no rate is known for %s", m.Currency))
+	}
+	dest, ok := t.Rate(to)
+	if !ok {
+		return nil, errors.Join(ErrUnknownCurrency, fmt.Errorf("no rate is known for %s", to))
+	}
+	v, _ := m.Amount.Mul(from).Div(dest)
+	return NewMoney(v, to), nil
+}
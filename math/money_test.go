@@ -0,0 +1,85 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := NewMoney(NewFraction(25, 2), "EUR")
+	sum, err := price.Add(NewMoney(NewFraction(1, 10), "EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "63/5 EUR" || sum.FixedString(2, HalfEven) != "12.60 EUR" {
+		t.Errorf("got %s and %s", sum, sum.FixedString(2, HalfEven))
+	}
+	if sum.LaTeX(2, HalfEven) != `12.60\,\mathrm{EUR}` {
+		t.Errorf("got %s", sum.LaTeX(2, HalfEven))
+	}
+	third, err := price.Div(NewMoney(IntToFraction(3), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.Currency != "EUR" || third.FixedString(2, TowardZero) != "4.16 EUR" {
+		t.Errorf("got %s", third.FixedString(2, TowardZero))
+	}
+	ratio, err := price.Div(third)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ratio.IsNumber() || ratio.String() != "3" {
+		t.Errorf("got %s; want 3", ratio)
+	}
+
+	usd := NewMoney(OneFraction, "USD")
+	if _, err = price.Add(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("excepted currency mismatch, got %v", err)
+	}
+	if _, err = price.Mul(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("excepted currency mismatch, got %v", err)
+	}
+	if _, err = price.Exp(IntToFraction(2)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("excepted currency mismatch, got %v", err)
+	}
+	if c, err := price.Cmp(third); err != nil || c != 1 {
+		t.Errorf("got %d, %v; want 1", c, err)
+	}
+	if _, err = price.Cmp(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("excepted currency mismatch, got %v", err)
+	}
+}
+
+func TestRateTable_Convert(t *testing.T) {
+	rates := NewRateTable("EUR")
+	if err := rates.Set("USD", NewFraction(92, 100)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rates.Set("GBP", NewFraction(117, 100)); err != nil {
+		t.Fatal(err)
+	}
+	genericTest := func(m *Money, to, excepted string) {
+		got, err := rates.Convert(m, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.FixedString(2, HalfEven) != excepted {
+			t.Errorf("%s in %s: got %s; want %s", m, to, got.FixedString(2, HalfEven), excepted)
+		}
+	}
+	genericTest(NewMoney(IntToFraction(100), "USD"), "EUR", "92.00 EUR")
+	genericTest(NewMoney(IntToFraction(100), "EUR"), "USD", "108.70 USD")
+	genericTest(NewMoney(IntToFraction(100), "GBP"), "USD", "127.17 USD")
+
+	if _, err := rates.Convert(NewMoney(OneFraction, "JPY"), "EUR"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("excepted unknown currency, got %v", err)
+	}
+	for _, code := range []string{"usd", "XYZ", "EURO"} {
+		if err := rates.Set(code, OneFraction); !errors.Is(err, ErrUnknownCurrency) {
+			t.Errorf("%s: excepted unknown currency, got %v", code, err)
+		}
+	}
+	if err := rates.Set("CHF", NullFraction); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+}
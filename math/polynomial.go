@@ -0,0 +1,557 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+const (
+	// maxDivisorSearch is the biggest integer whose divisors are searched to find rational roots and factors
+	maxDivisorSearch = 1 << 40
+	// maxKroneckerCombinations is the maximum number of candidates tested by Kronecker's method for each degree
+	maxKroneckerCombinations = 100_000
+)
+
+// Polynomial is a polynomial in one variable with rational coefficients, like x^2 - 3x/2 + 1
+type Polynomial struct {
+	// coefs[i] is the coefficient of x^i, the leading coefficient is never null
+	coefs []*Fraction
+	x     string
+}
+
+// PolynomialFactor is the factor Factor^Multiplicity of a factorisation
+type PolynomialFactor struct {
+	Factor       *Polynomial
+	Multiplicity int
+}
+
+// NewPolynomial creates the Polynomial in the variable x with the given coefficients, the i-th being the one of x^i.
+// NewPolynomial("x", NewFraction(1, 1), NullFraction, NewFraction(2, 1)) is 2x^2 + 1.
+func NewPolynomial(x string, coefs ...*Fraction) *Polynomial {
+	p := &Polynomial{make([]*Fraction, len(coefs)), x}
+	for i, c := range coefs {
+		p.coefs[i] = c.Copy()
+	}
+	return p.normalize()
+}
+
+// normalize removes the null leading coefficients
+func (p *Polynomial) normalize() *Polynomial {
+	for len(p.coefs) > 0 && p.coefs[len(p.coefs)-1].Sign() == 0 {
+		p.coefs = p.coefs[:len(p.coefs)-1]
+	}
+	return p
+}
+
+func (p *Polynomial) new(coefs []*Fraction) *Polynomial {
+	return (&Polynomial{coefs, p.x}).normalize()
+}
+
+// Variable returns the name of the variable of the Polynomial
+func (p *Polynomial) Variable() string {
+	return p.x
+}
+
+// Degree returns the degree of the Polynomial, -1 for the null Polynomial
+func (p *Polynomial) Degree() int {
+	return len(p.coefs) - 1
+}
+
+// Coefficient returns the coefficient of x^i
+func (p *Polynomial) Coefficient(i int) *Fraction {
+	if i < 0 || i >= len(p.coefs) {
+		return NullFraction
+	}
+	return p.coefs[i].Copy()
+}
+
+// LeadingCoefficient returns the coefficient of the highest power of x (0 for the null Polynomial)
+func (p *Polynomial) LeadingCoefficient() *Fraction {
+	return p.Coefficient(p.Degree())
+}
+
+// IsNull returns true if the Polynomial is 0
+func (p *Polynomial) IsNull() bool {
+	return len(p.coefs) == 0
+}
+
+// Is returns true if both Polynomial have the same coefficients
+func (p *Polynomial) Is(a *Polynomial) bool {
+	return slices.EqualFunc(p.coefs, a.coefs, func(c, d *Fraction) bool {
+		return c.Is(d)
+	})
+}
+
+func (p *Polynomial) Add(a *Polynomial) *Polynomial {
+	res := make([]*Fraction, max(len(p.coefs), len(a.coefs)))
+	for i := range res {
+		res[i] = p.Coefficient(i).Add(a.Coefficient(i))
+	}
+	return p.new(res)
+}
+
+func (p *Polynomial) Neg() *Polynomial {
+	return p.Scale(IntToFraction(-1))
+}
+
+func (p *Polynomial) Sub(a *Polynomial) *Polynomial {
+	return p.Add(a.Neg())
+}
+
+// Scale returns the Polynomial multiplied by the number k
+func (p *Polynomial) Scale(k *Fraction) *Polynomial {
+	res := make([]*Fraction, len(p.coefs))
+	for i, c := range p.coefs {
+		res[i] = c.Mul(k)
+	}
+	return p.new(res)
+}
+
+func (p *Polynomial) Mul(a *Polynomial) *Polynomial {
+	if p.IsNull() || a.IsNull() {
+		return p.new(nil)
+	}
+	res := make([]*Fraction, len(p.coefs)+len(a.coefs)-1)
+	for i := range res {
+		res[i] = NullFraction
+	}
+	for i, c := range p.coefs {
+		for j, d := range a.coefs {
+			res[i+j] = res[i+j].Add(c.Mul(d))
+		}
+	}
+	return p.new(res)
+}
+
+// DivMod returns the quotient and the remainder of the euclidean division of the Polynomial by a.
+// Returns ErrIllegalOperation if a is null.
+func (p *Polynomial) DivMod(a *Polynomial) (*Polynomial, *Polynomial, error) {
+	if a.IsNull() {
+		return nil, nil, errors.Join(ErrIllegalOperation, fmt.Errorf("(%s)/0", p))
+	}
+	r := p.new(slices.Clone(p.coefs))
+	if p.Degree() < a.Degree() {
+		return p.new(nil), r, nil
+	}
+	q := make([]*Fraction, p.Degree()-a.Degree()+1)
+	for i := range q {
+		q[i] = NullFraction
+	}
+	lc := a.LeadingCoefficient()
+	for !r.IsNull() && r.Degree() >= a.Degree() {
+		k, _ := r.LeadingCoefficient().Div(lc)
+		n := r.Degree() - a.Degree()
+		q[n] = k
+		sub := make([]*Fraction, n+len(a.coefs))
+		for i := range sub {
+			sub[i] = NullFraction
+		}
+		for i, c := range a.coefs {
+			sub[n+i] = c.Mul(k)
+		}
+		r = r.Sub(p.new(sub))
+	}
+	return p.new(q), r, nil
+}
+
+// GCD returns the monic greatest common divisor of the Polynomial and a (0 if both are null)
+func (p *Polynomial) GCD(a *Polynomial) *Polynomial {
+	x, y := p, a
+	for !y.IsNull() {
+		_, r, _ := x.DivMod(y)
+		x, y = y, r
+	}
+	return x.Monic()
+}
+
+// Monic returns the Polynomial divided by its leading coefficient
+func (p *Polynomial) Monic() *Polynomial {
+	if p.IsNull() {
+		return p
+	}
+	inv, _ := p.LeadingCoefficient().Inv()
+	return p.Scale(inv)
+}
+
+// Derivative returns the derivative of the Polynomial
+func (p *Polynomial) Derivative() *Polynomial {
+	if p.Degree() < 1 {
+		return p.new(nil)
+	}
+	res := make([]*Fraction, len(p.coefs)-1)
+	for i := range res {
+		res[i] = p.coefs[i+1].Mul(IntToFraction(int64(i + 1)))
+	}
+	return p.new(res)
+}
+
+// Eval returns the value of the Polynomial at x, computed with Horner's method
+func (p *Polynomial) Eval(x *Fraction) *Fraction {
+	res := NullFraction
+	for i := len(p.coefs) - 1; i >= 0; i-- {
+		res = res.Mul(x).Add(p.coefs[i])
+	}
+	return res
+}
+
+// primitive returns the Polynomial with coprime integer coefficients and a positive leading coefficient, and k such
+// that p = k * primitive
+func (p *Polynomial) primitive() (*Polynomial, *Fraction) {
+	if p.IsNull() {
+		return p, OneFraction
+	}
+	lcm := big.NewInt(1)
+	for _, c := range p.coefs {
+		g := new(big.Int).GCD(nil, nil, lcm, c.Denom())
+		lcm.Mul(lcm, new(big.Int).Quo(c.Denom(), g))
+	}
+	gcd := new(big.Int)
+	for _, c := range p.coefs {
+		n := new(big.Int).Mul(c.Num(), new(big.Int).Quo(lcm, c.Denom()))
+		gcd.GCD(nil, nil, gcd, n.Abs(n))
+	}
+	k := &Fraction{new(big.Rat).SetFrac(gcd, lcm)}
+	if p.LeadingCoefficient().Sign() < 0 {
+		k = k.Neg()
+	}
+	inv, _ := k.Inv()
+	return p.Scale(inv), k
+}
+
+// RationalRoots returns the distinct rational roots of the Polynomial, in increasing order.
+// The roots are searched with the rational root theorem, so huge coefficients may hide some of them.
+func (p *Polynomial) RationalRoots() []*Fraction {
+	if p.Degree() < 1 {
+		return nil
+	}
+	q, _ := p.primitive()
+	var roots []*Fraction
+	if q.coefs[0].Sign() == 0 {
+		roots = append(roots, NullFraction)
+		// removes the factors x
+		i := 0
+		for q.coefs[i].Sign() == 0 {
+			i++
+		}
+		q = q.new(q.coefs[i:])
+	}
+	nums, ok := divisors(q.coefs[0].Num())
+	if !ok {
+		return roots
+	}
+	dens, ok := divisors(q.LeadingCoefficient().Num())
+	if !ok {
+		return roots
+	}
+	for _, n := range nums {
+		for _, d := range dens {
+			for _, sign := range []int64{1, -1} {
+				r := &Fraction{new(big.Rat).SetFrac(new(big.Int).Mul(n, big.NewInt(sign)), d)}
+				if q.Eval(r).Sign() != 0 || slices.ContainsFunc(roots, r.Is) {
+					continue
+				}
+				roots = append(roots, r)
+			}
+		}
+	}
+	slices.SortFunc(roots, func(a, b *Fraction) int {
+		return a.Cmp(b.Rat)
+	})
+	return roots
+}
+
+// RealRootIntervals returns disjoint intervals [a ; b] in increasing order, each containing exactly one real root of the
+// Polynomial, which must be square-free: p(a) and p(b) are not null and have opposite signs.
+// The roots are counted with Sturm's theorem, so none of them is missed.
+func (p *Polynomial) RealRootIntervals() [][2]*Fraction {
+	if p.Degree() < 1 {
+		return nil
+	}
+	seq := []*Polynomial{p, p.Derivative()}
+	for {
+		_, r, _ := seq[len(seq)-2].DivMod(seq[len(seq)-1])
+		if r.IsNull() {
+			break
+		}
+		seq = append(seq, r.Neg())
+	}
+	// every root is strictly inside ]-bound ; bound[ (Cauchy's bound)
+	bound := OneFraction
+	lc := p.LeadingCoefficient()
+	for _, c := range p.coefs[:p.Degree()] {
+		v, _ := c.Div(lc)
+		if v.Sign() < 0 {
+			v = v.Neg()
+		}
+		if v.Add(OneFraction).GreaterThan(bound) {
+			bound = v.Add(OneFraction)
+		}
+	}
+	var res [][2]*Fraction
+	p.isolate(seq, bound.Neg(), bound, signChanges(seq, bound.Neg()), signChanges(seq, bound), &res)
+	return res
+}
+
+// isolate appends the intervals isolating the va - vb roots in ]a ; b] to res, va and vb being the numbers of sign
+// changes of the Sturm sequence at a and b
+func (p *Polynomial) isolate(seq []*Polynomial, a, b *Fraction, va, vb int, res *[][2]*Fraction) {
+	switch va - vb {
+	case 0:
+		return
+	case 1:
+		*res = append(*res, [2]*Fraction{a, b})
+		return
+	}
+	// the split point must not be a root, so it stays a valid bound: one of the Degree() + 1 first points is not
+	m := a.Add(b.Sub(a).Mul(NewFraction(1, 2)))
+	for k := int64(3); p.Eval(m).Sign() == 0; k++ {
+		m = a.Add(b.Sub(a).Mul(NewFraction(1, k)))
+	}
+	vm := signChanges(seq, m)
+	p.isolate(seq, a, m, va, vm, res)
+	p.isolate(seq, m, b, vm, vb, res)
+}
+
+// signChanges returns the number of sign changes of the values of the polynomials at x, the null values being skipped
+func signChanges(seq []*Polynomial, x *Fraction) int {
+	n, last := 0, 0
+	for _, q := range seq {
+		s := q.Eval(x).Sign()
+		if s == 0 {
+			continue
+		}
+		if last != 0 && s != last {
+			n++
+		}
+		last = s
+	}
+	return n
+}
+
+// divisors returns the positive divisors of n.
+// ok is false if n is too big.
+func divisors(n *big.Int) ([]*big.Int, bool) {
+	n = new(big.Int).Abs(n)
+	if n.Cmp(big.NewInt(maxDivisorSearch)) > 0 {
+		return nil, false
+	}
+	v := n.Int64()
+	var small, large []*big.Int
+	for d := int64(1); d*d <= v; d++ {
+		if v%d != 0 {
+			continue
+		}
+		small = append(small, big.NewInt(d))
+		if d*d != v {
+			large = append(large, big.NewInt(v/d))
+		}
+	}
+	slices.Reverse(large)
+	return append(small, large...), true
+}
+
+// SquareFree returns the square-free decomposition of the Polynomial, computed with Yun's algorithm.
+// The factors are monic, pairwise coprime and without repeated roots: the Polynomial is its leading coefficient times
+// the product of the factors raised to their multiplicity.
+func (p *Polynomial) SquareFree() []*PolynomialFactor {
+	if p.Degree() < 1 {
+		return nil
+	}
+	var res []*PolynomialFactor
+	d := p.Derivative()
+	a := p.GCD(d)
+	b, _, _ := p.DivMod(a)
+	c, _, _ := d.DivMod(a)
+	c = c.Sub(b.Derivative())
+	for i := 1; b.Degree() > 0; i++ {
+		a = b.GCD(c)
+		b, _, _ = b.DivMod(a)
+		c, _, _ = c.DivMod(a)
+		c = c.Sub(b.Derivative())
+		if a.Degree() > 0 {
+			res = append(res, &PolynomialFactor{a.Monic(), i})
+		}
+	}
+	return res
+}
+
+// Factor returns the factorisation of the Polynomial over the rationals: the Polynomial is k times the product of the
+// factors raised to their multiplicity.
+// The factors have coprime integer coefficients and a positive leading coefficient, they are sorted by degree.
+// The factors of degree higher than 1 are searched with Kronecker's method, so a factor of a Polynomial with huge
+// coefficients may not be found.
+func (p *Polynomial) Factor() (*Fraction, []*PolynomialFactor) {
+	var res []*PolynomialFactor
+	k := p.LeadingCoefficient()
+	for _, sf := range p.SquareFree() {
+		for _, f := range sf.Factor.irreducibleFactors() {
+			f, _ = f.primitive()
+			lc, _ := f.LeadingCoefficient().Exp(IntToFraction(int64(sf.Multiplicity)))
+			k, _ = k.Div(lc)
+			res = append(res, &PolynomialFactor{f, sf.Multiplicity})
+		}
+	}
+	slices.SortStableFunc(res, func(a, b *PolynomialFactor) int {
+		if a.Factor.Degree() != b.Factor.Degree() {
+			return a.Factor.Degree() - b.Factor.Degree()
+		}
+		return strings.Compare(a.Factor.String(), b.Factor.String())
+	})
+	return k, res
+}
+
+// irreducibleFactors splits a square-free Polynomial into irreducible factors
+func (p *Polynomial) irreducibleFactors() []*Polynomial {
+	var res []*Polynomial
+	for _, r := range p.RationalRoots() {
+		f := p.new([]*Fraction{r.Neg(), OneFraction})
+		res = append(res, f)
+		p, _, _ = p.DivMod(f)
+	}
+	if p.Degree() < 1 {
+		return res
+	}
+	return append(res, p.kronecker()...)
+}
+
+// kronecker splits a Polynomial without rational roots with Kronecker's method: a factor of degree d is interpolated
+// from the divisors of the values of the Polynomial at 0, 1, ..., d
+func (p *Polynomial) kronecker() []*Polynomial {
+	q, _ := p.primitive()
+	for d := 2; d <= q.Degree()/2; d++ {
+		candidates := make([][]*big.Int, d+1)
+		total := 1
+		for j := range candidates {
+			v := q.Eval(IntToFraction(int64(j)))
+			divs, ok := divisors(v.Num())
+			if !ok || total*2*len(divs) > maxKroneckerCombinations {
+				total = 0
+				break
+			}
+			total *= 2 * len(divs)
+			for _, div := range divs {
+				candidates[j] = append(candidates[j], div, new(big.Int).Neg(div))
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		values := make([]*Fraction, d+1)
+		var search func(j int) *Polynomial
+		search = func(j int) *Polynomial {
+			if j > d {
+				h := q.interpolate(values)
+				if h.Degree() != d {
+					return nil
+				}
+				if _, r, _ := q.DivMod(h); !r.IsNull() {
+					return nil
+				}
+				return h
+			}
+			for _, c := range candidates[j] {
+				if j == 0 && c.Sign() < 0 {
+					// h and -h are the same factor
+					continue
+				}
+				values[j] = &Fraction{new(big.Rat).SetInt(c)}
+				if h := search(j + 1); h != nil {
+					return h
+				}
+			}
+			return nil
+		}
+		if h := search(0); h != nil {
+			quo, _, _ := q.DivMod(h)
+			return append(h.kronecker(), quo.kronecker()...)
+		}
+	}
+	return []*Polynomial{q}
+}
+
+// interpolate returns the Polynomial of degree at most len(values) - 1 taking values[j] at j (Lagrange interpolation)
+func (p *Polynomial) interpolate(values []*Fraction) *Polynomial {
+	res := p.new(nil)
+	for j, v := range values {
+		l := p.new([]*Fraction{OneFraction})
+		for k := range values {
+			if k == j {
+				continue
+			}
+			inv, _ := IntToFraction(int64(j - k)).Inv()
+			l = l.Mul(p.new([]*Fraction{IntToFraction(int64(-k)).Mul(inv), inv}))
+		}
+		res = res.Add(l.Scale(v))
+	}
+	return res
+}
+
+// String returns the Polynomial in the form 3x^2/2 - x + 1
+func (p *Polynomial) String() string {
+	return p.render(func(c *Fraction, i int) string {
+		num := new(big.Int).Abs(c.Num())
+		s := ""
+		if i == 0 || num.Cmp(big.NewInt(1)) != 0 {
+			s = num.String()
+		}
+		if i > 0 {
+			s += p.x
+		}
+		if i > 1 {
+			s += fmt.Sprintf("^%d", i)
+		}
+		if !c.IsInt() {
+			s += "/" + c.Denom().String()
+		}
+		return s
+	})
+}
+
+// LaTeX returns the LaTeX representation of the Polynomial, like \frac{3}{2}x^{2} - x + 1
+func (p *Polynomial) LaTeX() string {
+	return p.render(func(c *Fraction, i int) string {
+		abs := c.Copy()
+		abs.Rat.Abs(abs.Rat)
+		s := ""
+		switch {
+		case !abs.IsInt():
+			s = fmt.Sprintf(`\frac{%s}{%s}`, abs.Num(), abs.Denom())
+		case i == 0 || !abs.Is(OneFraction):
+			s = abs.String()
+		}
+		if i > 0 {
+			s += p.x
+		}
+		if i > 1 {
+			s += fmt.Sprintf("^{%d}", i)
+		}
+		return s
+	})
+}
+
+// render writes the terms from the highest degree, term returning the representation of c*x^i without its sign
+func (p *Polynomial) render(term func(c *Fraction, i int) string) string {
+	if p.IsNull() {
+		return "0"
+	}
+	var sb strings.Builder
+	for i := len(p.coefs) - 1; i >= 0; i-- {
+		c := p.coefs[i]
+		if c.Sign() == 0 {
+			continue
+		}
+		switch {
+		case i == len(p.coefs)-1 && c.Sign() < 0:
+			sb.WriteString("-")
+		case i != len(p.coefs)-1 && c.Sign() < 0:
+			sb.WriteString(" - ")
+		case i != len(p.coefs)-1:
+			sb.WriteString(" + ")
+		}
+		sb.WriteString(term(c, i))
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+// intPolynomial returns the Polynomial in x with the given integer coefficients, the i-th being the one of x^i
+func intPolynomial(coefs ...int64) *Polynomial {
+	fracs := make([]*Fraction, len(coefs))
+	for i, c := range coefs {
+		fracs[i] = IntToFraction(c)
+	}
+	return NewPolynomial("x", fracs...)
+}
+
+func TestPolynomial_Arithmetic(t *testing.T) {
+	a := intPolynomial(-1, 0, 1)
+	b := intPolynomial(1, 1)
+	if got := a.Add(b).String(); got != "x^2 + x" {
+		t.Errorf("got %s; want x^2 + x", got)
+	}
+	if got := a.Sub(a); !got.IsNull() || got.Degree() != -1 {
+		t.Errorf("got %s; want 0", got)
+	}
+	if got := a.Mul(b).String(); got != "x^3 + x^2 - x - 1" {
+		t.Errorf("got %s; want x^3 + x^2 - x - 1", got)
+	}
+	q, r, err := intPolynomial(1, 2, 3, 4).DivMod(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.String() != "4x^2 - x + 3" || r.String() != "-2" {
+		t.Errorf("got %s and %s; want 4x^2 - x + 3 and -2", q, r)
+	}
+	if _, _, err = a.DivMod(intPolynomial()); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+	if got := a.GCD(intPolynomial(2, 4, 2)).String(); got != "x + 1" {
+		t.Errorf("got %s; want x + 1", got)
+	}
+	if got := intPolynomial(5, 3, 0, 2).Derivative().String(); got != "6x^2 + 3" {
+		t.Errorf("got %s; want 6x^2 + 3", got)
+	}
+	if got := a.Eval(NewFraction(1, 2)); !got.Is(NewFraction(-3, 4)) {
+		t.Errorf("got %s; want -3/4", got)
+	}
+}
+
+func TestPolynomial_String(t *testing.T) {
+	p := NewPolynomial("t", NewFraction(1, 2), IntToFraction(-1), NullFraction, NewFraction(-3, 2))
+	if got := p.String(); got != "-3t^3/2 - t + 1/2" {
+		t.Errorf("got %s; want -3t^3/2 - t + 1/2", got)
+	}
+	if got := p.LaTeX(); got != `-\frac{3}{2}t^{3} - t + \frac{1}{2}` {
+		t.Errorf(`got %s; want -\frac{3}{2}t^{3} - t + \frac{1}{2}`, got)
+	}
+	if got := intPolynomial().String(); got != "0" {
+		t.Errorf("got %s; want 0", got)
+	}
+}
+
+func TestPolynomial_RationalRoots(t *testing.T) {
+	genericTest := func(p *Polynomial, excepted ...*Fraction) {
+		roots := p.RationalRoots()
+		if len(roots) != len(excepted) {
+			t.Fatalf("%s: got %v; want %v", p, roots, excepted)
+		}
+		for i, r := range roots {
+			if !r.Is(excepted[i]) {
+				t.Errorf("%s: got %v; want %v", p, roots, excepted)
+			}
+		}
+	}
+	genericTest(intPolynomial(-1, 0, 1), IntToFraction(-1), IntToFraction(1))
+	genericTest(intPolynomial(0, 0, -1, 1), IntToFraction(0), IntToFraction(1))
+	genericTest(intPolynomial(-3, 2, 8), NewFraction(-3, 4), NewFraction(1, 2))
+	genericTest(intPolynomial(2, 0, 1))
+}
+
+func TestPolynomial_Factor(t *testing.T) {
+	genericTest := func(p *Polynomial, k *Fraction, excepted ...string) {
+		c, factors := p.Factor()
+		if !c.Is(k) {
+			t.Errorf("%s: got constant %s; want %s", p, c, k)
+		}
+		var got []string
+		for _, f := range factors {
+			got = append(got, f.Factor.String()+"^"+IntToFraction(int64(f.Multiplicity)).String())
+		}
+		if len(got) != len(excepted) {
+			t.Fatalf("%s: got %v; want %v", p, got, excepted)
+		}
+		for i := range got {
+			if got[i] != excepted[i] {
+				t.Errorf("%s: got %v; want %v", p, got, excepted)
+			}
+		}
+	}
+	genericTest(intPolynomial(1, 2, 1), OneFraction, "x + 1^2")
+	genericTest(NewPolynomial("x", NewFraction(-1, 4), NullFraction, OneFraction), NewFraction(1, 4),
+		"2x + 1^1", "2x - 1^1")
+	genericTest(intPolynomial(4, 0, 0, 0, 1), OneFraction, "x^2 + 2x + 2^1", "x^2 - 2x + 2^1")
+	genericTest(intPolynomial(-1, 0, 0, 0, 0, 0, 1), OneFraction,
+		"x + 1^1", "x - 1^1", "x^2 + x + 1^1", "x^2 - x + 1^1")
+	genericTest(intPolynomial(0, 0, -2, 2), IntToFraction(2), "x^2", "x - 1^1")
+	genericTest(intPolynomial(-2, 0, 0, 0, 1), OneFraction, "x^4 - 2^1")
+
+	sf := intPolynomial(6, -5, 1).Mul(intPolynomial(6, -5, 1)).Mul(intPolynomial(1, 0, 1)).SquareFree()
+	if len(sf) != 2 || sf[0].Factor.String() != "x^2 + 1" || sf[1].Factor.String() != "x^2 - 5x + 6" ||
+		sf[1].Multiplicity != 2 {
+		t.Errorf("wrong square-free decomposition of (x^2 - 5x + 6)^2 (x^2 + 1)")
+	}
+}
+
+func TestPolynomial_RealRootIntervals(t *testing.T) {
+	genericTest := func(p *Polynomial, excepted int) {
+		intervals := p.RealRootIntervals()
+		if len(intervals) != excepted {
+			t.Fatalf("%s: got %d intervals; want %d", p, len(intervals), excepted)
+		}
+		for i, in := range intervals {
+			if p.Eval(in[0]).Sign()*p.Eval(in[1]).Sign() >= 0 {
+				t.Errorf("%s: no sign change on [%s ; %s]", p, in[0], in[1])
+			}
+			if i > 0 && in[0].SmallerThan(intervals[i-1][1]) {
+				t.Errorf("%s: [%s ; %s] overlaps the previous interval", p, in[0], in[1])
+			}
+		}
+	}
+	genericTest(intPolynomial(-2, 0, 0, 1), 1)
+	genericTest(intPolynomial(-2, 0, 0, 0, 1), 2)
+	genericTest(intPolynomial(1, 0, 1), 0)
+	// three close roots: 0, 1/1000 and 2/1000
+	genericTest(intPolynomial(0, 2, -3000, 1_000_000), 3)
+	// the roots of x^3 - x are the first split points
+	genericTest(intPolynomial(0, -1, 0, 1), 3)
+}
@@ -0,0 +1,222 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+)
+
+const (
+	// maxQuadratureLevel is the maximum number of times the step of the quadrature is halved
+	maxQuadratureLevel = 10
+	// maxIntegralPrecision is the maximum working precision of an integral, in bits (about 300 digits)
+	maxIntegralPrecision = 1024
+)
+
+// RealFunction is a real function of a real variable, integrated by Integrate or solved by NewRoot: it returns f(x)
+type RealFunction func(x *Fraction) (*Real, error)
+
+// quadratureNode is a node of the tanh-sinh quadrature on [-1 ; 1]: the abscissas are ±(1 - q) and the weight is w
+type quadratureNode struct {
+	q *big.Rat
+	w *big.Float
+}
+
+var (
+	// quadratureNodes caches the nodes of each level by working precision
+	quadratureNodes   = map[uint][][]*quadratureNode{}
+	quadratureNodesMu sync.Mutex
+)
+
+// nodesOfLevel returns the nodes added by the level: t = k*2^-level with k odd (every k >= 0 for the level 0)
+func nodesOfLevel(level int, prec uint) []*quadratureNode {
+	quadratureNodesMu.Lock()
+	defer quadratureNodesMu.Unlock()
+	levels := quadratureNodes[prec]
+	for len(levels) <= level {
+		levels = append(levels, computeNodes(len(levels), prec))
+	}
+	quadratureNodes[prec] = levels
+	return levels[level]
+}
+
+// computeNodes computes the nodes of the level, until q is negligible
+func computeNodes(level int, prec uint) []*quadratureNode {
+	halfPi := Pi.approx(prec)
+	halfPi.SetMantExp(halfPi, -1)
+	// f(x) can diverge like 1/sqrt(x) at the bounds, so q must be smaller than 2^(-2prec)
+	minExp := -2 * int(prec)
+	first, step := 1, 2
+	if level == 0 {
+		first, step = 0, 1
+	}
+	var nodes []*quadratureNode
+	for k := first; ; k += step {
+		t := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(float64(k)), -level)
+		sinh, cosh := sinhCoshFloat(t, prec)
+		// q = 1 - tanh(u) = 2/(e^(2u) + 1) with u = pi/2 sinh(t)
+		u := new(big.Float).SetPrec(prec).Mul(halfPi, sinh)
+		e := expFloat(u.SetMantExp(u, 1), prec)
+		q := new(big.Float).SetPrec(prec).Quo(big.NewFloat(2), e.Add(e, big.NewFloat(1)))
+		if q.Sign() == 0 || q.MantExp(nil) < minExp {
+			return nodes
+		}
+		// w = pi/2 cosh(t) (1 - tanh(u)^2) = pi/2 cosh(t) q (2 - q)
+		w := new(big.Float).SetPrec(prec).Sub(big.NewFloat(2), q)
+		w.Mul(w, q).Mul(w, cosh).Mul(w, halfPi)
+		r, _ := q.Rat(nil)
+		nodes = append(nodes, &quadratureNode{r, w})
+	}
+}
+
+// Integrate computes the integral of f between a and b with a tanh-sinh quadrature, with a working precision of at
+// least prec bits.
+// The tanh-sinh quadrature is used instead of an adaptive Simpson or Gauss–Kronrod one because its number of correct
+// digits doubles at each level, so it stays fast with hundreds of digits, and because its nodes cluster at the bounds,
+// so the functions diverging there, like 1/sqrt(x) on [0 ; 1], are integrated with the same precision.
+// The step is halved until the error, estimated from the difference of two successive estimates, is lower than 2^-prec
+// times the integral of |f|.
+// Returns the value of the integral and the estimate of its absolute error.
+// Returns ErrIllegalOperation if the quadrature does not converge, like when f has a singularity inside the interval,
+// and the errors of f.
+func Integrate(f RealFunction, a, b *Real, prec uint) (*big.Float, *big.Float, error) {
+	v, e, err := integrate(f, a, b, prec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, e, nil
+}
+
+// integrate is Integrate returning the last estimate even if the quadrature does not converge
+func integrate(f RealFunction, a, b *Real, prec uint) (*big.Float, *big.Float, error) {
+	// the nodes are shared by the close precisions
+	prec = (prec + 63) / 64 * 64
+	ra := &Fraction{Rat: new(big.Rat)}
+	rb := &Fraction{Rat: new(big.Rat)}
+	a.approx(prec).Rat(ra.Rat)
+	b.approx(prec).Rat(rb.Rat)
+	center := ra.Add(rb).Mul(NewFraction(1, 2))
+	half := rb.Sub(ra).Mul(NewFraction(1, 2))
+	zero := new(big.Float).SetPrec(prec)
+	if half.Sign() == 0 {
+		return zero, zero, nil
+	}
+	sum := new(big.Float).SetPrec(prec)
+	sumAbs := new(big.Float).SetPrec(prec)
+	add := func(x *Fraction, w *big.Float) error {
+		r, err := f(x)
+		if err != nil {
+			return err
+		}
+		y := r.approx(prec)
+		y.Mul(y, w)
+		sum.Add(sum, y)
+		sumAbs.Add(sumAbs, y.Abs(y))
+		return nil
+	}
+	var last *big.Float
+	for level := 0; level <= maxQuadratureLevel; level++ {
+		for i, n := range nodesOfLevel(level, prec) {
+			if level == 0 && i == 0 {
+				// t = 0 is the center
+				if err := add(center, n.w); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			dx := half.Mul(&Fraction{Rat: new(big.Rat).Sub(big.NewRat(1, 1), n.q)})
+			if err := add(center.Sub(dx), n.w); err != nil {
+				return nil, nil, err
+			}
+			if err := add(center.Add(dx), n.w); err != nil {
+				return nil, nil, err
+			}
+		}
+		// the integral is h*half*sum, with h = 2^-level
+		scale := new(big.Float).SetPrec(prec).SetRat(half.Rat)
+		scale.SetMantExp(scale, -level)
+		value := new(big.Float).SetPrec(prec).Mul(sum, scale)
+		norm := new(big.Float).SetPrec(prec).Mul(sumAbs, scale)
+		norm.Abs(norm)
+		if last != nil && level >= 3 {
+			diff := new(big.Float).SetPrec(prec).Sub(value, last)
+			diff.Abs(diff)
+			// the number of correct digits doubles at each level, so the error of value is about diff^2/norm
+			tol := new(big.Float).SetPrec(prec).SetMantExp(norm, -int(prec)/2)
+			if diff.Cmp(tol) <= 0 {
+				if norm.Sign() != 0 {
+					diff.Quo(diff.Mul(diff, diff), norm)
+				}
+				return value, diff, nil
+			}
+		}
+		last = value
+	}
+	return last, nil, errors.Join(
+		ErrIllegalOperation,
+		fmt.Errorf("the integral does not converge with %d bits: the function may have a singularity between %s and %s", prec, a, b),
+	)
+}
+
+// integralAtom is an integral kept symbolic, like integrate(x^2, x, 0, 1).
+// Its approximation is computed by Integrate with the precision requested and cached.
+type integralAtom struct {
+	// id identifies the integral, including the values of its variables
+	id, name, latex string
+	f               RealFunction
+	a, b            *Real
+
+	mu     sync.Mutex
+	cached *big.Float
+}
+
+func (i *integralAtom) key() string {
+	return i.id
+}
+
+func (i *integralAtom) String() string {
+	return i.name
+}
+
+func (i *integralAtom) LaTeX() string {
+	return i.latex
+}
+
+func (i *integralAtom) approx(prec uint) *big.Float {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	// the working precision is bounded, so the Real loops comparing precisions end
+	wp := min(prec, maxIntegralPrecision)
+	if i.cached == nil || i.cached.Prec() < wp {
+		// the convergence was checked by NewIntegral: the last estimate is the best one
+		v, _, err := integrate(i.f, i.a, i.b, wp)
+		if err == nil || v != nil {
+			i.cached = v
+		}
+	}
+	return new(big.Float).SetPrec(prec).Set(i.cached)
+}
+
+// NewIntegral returns the integral of f between a and b as an irrational Real: its digits are computed by Integrate
+// when they are requested, like the ones of pi.
+// The quadrature is checked to converge with the given number of decimal digits.
+// id identifies the integral (two integrals having the same id are equal), name and latex are its representations.
+// Returns the integral and the estimate of the absolute error of the quadrature with this precision.
+// Returns ErrIllegalOperation if the precision is greater than about 300 digits and the errors of Integrate.
+func NewIntegral(f RealFunction, a, b *Real, precision int, id, name, latex string) (*Real, *big.Float, error) {
+	prec := uint(float64(max(precision, 0))*math.Log2(10)) + guardBits
+	if prec > maxIntegralPrecision {
+		return nil, nil, errors.Join(ErrIllegalOperation, fmt.Errorf("integrals cannot be computed with %d digits", precision))
+	}
+	if a.Is(b) {
+		return NullReal, new(big.Float), nil
+	}
+	v, e, err := Integrate(f, a, b, prec)
+	if err != nil {
+		return nil, nil, err
+	}
+	i := &integralAtom{id: id, name: name, latex: latex, f: f, a: a, b: b, cached: v}
+	return newReal(&term{OneFraction, []*factor{{i, 1}}}), e, nil
+}
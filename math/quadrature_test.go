@@ -0,0 +1,57 @@
+package math
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewIntegral(t *testing.T) {
+	genericTest := func(name string, f RealFunction, a, b *Real, precision int, excepted string) {
+		r, e, err := NewIntegral(f, a, b, precision, name, name, name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if tol := new(big.Float).SetFloat64(math.Pow10(-precision)); e.Cmp(tol) > 0 {
+			t.Errorf("%s: the error estimate %s is greater than %s", name, e, tol)
+		}
+		if got := r.Approx(precision); got != excepted {
+			t.Errorf("%s: got %s; want %s", name, got, excepted)
+		}
+		if r.IsRational() {
+			t.Errorf("%s: an integral must not be exact", name)
+		}
+	}
+	square := func(x *Fraction) (*Real, error) { return FractionToReal(x.Mul(x)), nil }
+	sin := func(x *Fraction) (*Real, error) { return Sin(FractionToReal(x)) }
+	sqrt := func(x *Fraction) (*Real, error) { return Sqrt(FractionToReal(x)) }
+	gaussian := func(x *Fraction) (*Real, error) { return Exp(FractionToReal(x.Mul(x).Neg())) }
+	genericTest("x^2", square, NullReal, OneReal, 30, "0.333333333333333333333333333333")
+	genericTest("sin", sin, NullReal, Pi, 20, "2")
+	genericTest("reversed sin", sin, Pi, NullReal, 20, "-2")
+	genericTest("sqrt", sqrt, NullReal, OneReal, 20, "0.66666666666666666667")
+	genericTest("gaussian", gaussian, IntToReal(-10), IntToReal(10), 20, "1.7724538509055160273")
+
+	r, _, err := NewIntegral(sin, Pi, Pi, 10, "empty", "empty", "empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.IsNull() {
+		t.Errorf("got %s; want 0", r)
+	}
+
+	inv := func(x *Fraction) (*Real, error) {
+		i, err := x.Inv()
+		return FractionToReal(i), err
+	}
+	if _, _, err = NewIntegral(inv, IntToReal(-1), OneReal, 10, "1/x", "1/x", "1/x"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+	if _, _, err = NewIntegral(inv, NullReal, OneReal, 10, "1/x", "1/x", "1/x"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+	if _, _, err = NewIntegral(square, NullReal, OneReal, 1000, "x^2", "x^2", "x^2"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+}
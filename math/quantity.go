@@ -0,0 +1,279 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrDimensionMismatch is thrown when an operation mixes quantities of different dimensions, like 1 m + 1 s
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+
+	// baseUnits are the SI base units, in the order of a Dimension
+	baseUnits = [7]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+)
+
+// Dimension contains the exponents of the SI base units m, kg, s, A, K, mol and cd, like {1, 0, -2} for an
+// acceleration
+type Dimension [7]int64
+
+// IsNull returns true if the Dimension is the one of a number without unit
+func (d Dimension) IsNull() bool {
+	return d == Dimension{}
+}
+
+func (d Dimension) mul(a Dimension) Dimension {
+	for i := range d {
+		d[i] += a[i]
+	}
+	return d
+}
+
+func (d Dimension) scale(n int64) Dimension {
+	for i := range d {
+		d[i] *= n
+	}
+	return d
+}
+
+// String returns the SI base units of the Dimension, like m kg/s^2
+func (d Dimension) String() string {
+	var num, den []string
+	for i, e := range d {
+		switch {
+		case e == 1:
+			num = append(num, baseUnits[i])
+		case e == -1:
+			den = append(den, baseUnits[i])
+		case e > 0:
+			num = append(num, fmt.Sprintf("%s^%d", baseUnits[i], e))
+		case e < 0:
+			den = append(den, fmt.Sprintf("%s^%d", baseUnits[i], -e))
+		}
+	}
+	if len(num) == 0 {
+		// the units are written with negative exponents, like s^-1
+		for i, e := range d {
+			if e != 0 {
+				num = append(num, fmt.Sprintf("%s^%d", baseUnits[i], e))
+			}
+		}
+		return strings.Join(num, " ")
+	}
+	s := strings.Join(num, " ")
+	switch len(den) {
+	case 0:
+		return s
+	case 1:
+		return s + "/" + den[0]
+	}
+	return s + "/(" + strings.Join(den, " ") + ")"
+}
+
+// describe returns the SI base units of the Dimension, or "no unit" if it is null
+func (d Dimension) describe() string {
+	if d.IsNull() {
+		return "no unit"
+	}
+	return d.String()
+}
+
+// LaTeX returns the LaTeX representation of the SI base units of the Dimension, like \mathrm{m}\,\mathrm{s}^{-2}
+func (d Dimension) LaTeX() string {
+	var units []string
+	for i, e := range d {
+		switch e {
+		case 0:
+		case 1:
+			units = append(units, `\mathrm{`+baseUnits[i]+`}`)
+		default:
+			units = append(units, fmt.Sprintf(`\mathrm{%s}^{%d}`, baseUnits[i], e))
+		}
+	}
+	return strings.Join(units, `\,`)
+}
+
+// Quantity is a physical quantity, like 3 m: a value measured in the SI base units of its Dimension
+type Quantity struct {
+	// Value is the value measured in the SI base units
+	Value *Fraction
+	Dim   Dimension
+	// unit is the Unit used to display the Quantity (the SI base units if nil)
+	unit *Unit
+}
+
+// displayedIn returns the Quantity value of dim displayed in u, or in the SI base units if u is nil or does not have
+// this Dimension
+func displayedIn(value *Fraction, dim Dimension, u *Unit) *Quantity {
+	q := NewQuantity(value, dim)
+	if u != nil && u.Dim == dim && !dim.IsNull() {
+		q.unit = u
+	}
+	return q
+}
+
+// NewQuantity returns the Quantity value measured in the SI base units of dim
+func NewQuantity(value *Fraction, dim Dimension) *Quantity {
+	return &Quantity{Value: value, Dim: dim}
+}
+
+// IsDimensionless returns true if the Quantity is a number without unit
+func (q *Quantity) IsDimensionless() bool {
+	return q.Dim.IsNull()
+}
+
+func (q *Quantity) checkDimension(a *Quantity, op string) error {
+	if q.Dim != a.Dim {
+		return errors.Join(ErrDimensionMismatch, fmt.Errorf("%s %s %s: %s and %s are not compatible", q, op, a, q.Dim.describe(), a.Dim.describe()))
+	}
+	return nil
+}
+
+// unitOf returns the Unit displaying q, or the one displaying a if q is displayed in the SI base units
+func (q *Quantity) unitOf(a *Quantity) *Unit {
+	if q.unit != nil {
+		return q.unit
+	}
+	return a.unit
+}
+
+// Add returns the sum of the quantities, displayed in the unit of q.
+// Returns ErrDimensionMismatch if they do not have the same Dimension.
+func (q *Quantity) Add(a *Quantity) (*Quantity, error) {
+	if err := q.checkDimension(a, "+"); err != nil {
+		return nil, err
+	}
+	return displayedIn(q.Value.Add(a.Value), q.Dim, q.unitOf(a)), nil
+}
+
+// Sub returns the difference of the quantities, displayed in the unit of q.
+// Returns ErrDimensionMismatch if they do not have the same Dimension.
+func (q *Quantity) Sub(a *Quantity) (*Quantity, error) {
+	if err := q.checkDimension(a, "-"); err != nil {
+		return nil, err
+	}
+	return displayedIn(q.Value.Sub(a.Value), q.Dim, q.unitOf(a)), nil
+}
+
+// Cmp compares the quantities.
+// Returns -1 if q < a, 0 if q = a and +1 if q > a, and ErrDimensionMismatch if they do not have the same Dimension.
+func (q *Quantity) Cmp(a *Quantity) (int, error) {
+	if err := q.checkDimension(a, "compared with"); err != nil {
+		return 0, err
+	}
+	return q.Value.Cmp(a.Value.Rat), nil
+}
+
+func (q *Quantity) Neg() *Quantity {
+	return displayedIn(q.Value.Neg(), q.Dim, q.unit)
+}
+
+// Mul returns the product of the quantities.
+// A Quantity multiplied by a number keeps its unit, like 3 h.
+func (q *Quantity) Mul(a *Quantity) *Quantity {
+	return displayedIn(q.Value.Mul(a.Value), q.Dim.mul(a.Dim), q.unitOf(a))
+}
+
+// Div returns q/a.
+// A Quantity divided by a number keeps its unit and a Quantity divided by a unit is displayed in their quotient, like
+// km/h.
+// Returns ErrIllegalOperation if a is null.
+func (q *Quantity) Div(a *Quantity) (*Quantity, error) {
+	v, err := q.Value.Div(a.Value)
+	if err != nil {
+		return nil, err
+	}
+	u := q.unit
+	if a.unit != nil {
+		// m/s/s is displayed in the SI base units
+		u = nil
+		if q.unit != nil && !strings.Contains(q.unit.Name, "/") {
+			u = q.unit.per(a.unit)
+		}
+	}
+	return displayedIn(v, q.Dim.mul(a.Dim.scale(-1)), u), nil
+}
+
+// Exp returns q^a, like (4 m^2)^(1/2) = 2 m.
+// Returns ErrDimensionMismatch if the exponents of the units are not integers, like m^(1/2), and the errors of
+// Fraction.Exp.
+func (q *Quantity) Exp(a *Fraction) (*Quantity, error) {
+	var dim Dimension
+	for i, e := range q.Dim {
+		n := new(big.Rat).Mul(big.NewRat(e, 1), a.Rat)
+		if !n.IsInt() || !n.Num().IsInt64() {
+			return nil, errors.Join(ErrDimensionMismatch, fmt.Errorf("(%s)^(%s): %s^(%s) is not a unit", q, a, baseUnits[i], n.RatString()))
+		}
+		dim[i] = n.Num().Int64()
+	}
+	v, err := q.Value.Exp(a)
+	if err != nil {
+		return nil, err
+	}
+	return NewQuantity(v, dim), nil
+}
+
+// In returns the Quantity displayed in the Unit u.
+// Returns ErrDimensionMismatch if u does not have the same Dimension.
+func (q *Quantity) In(u *Unit) (*Quantity, error) {
+	if q.IsDimensionless() {
+		return nil, errors.Join(ErrDimensionMismatch, fmt.Errorf("%s has no unit: it cannot be converted in %s", q, u.Name))
+	}
+	if q.Dim != u.Dim {
+		return nil, errors.Join(
+			ErrDimensionMismatch,
+			fmt.Errorf("%s cannot be converted in %s: %s and %s are not compatible", q, u.Name, q.Dim.describe(), u.Dim.describe()),
+		)
+	}
+	return &Quantity{Value: q.Value, Dim: q.Dim, unit: u}, nil
+}
+
+// displayed returns the value measured in the unit displayed, with the name and the LaTeX of this unit
+func (q *Quantity) displayed() (*Fraction, string, string) {
+	if q.unit == nil {
+		return q.Value, q.Dim.String(), q.Dim.LaTeX()
+	}
+	v, _ := q.Value.Div(q.unit.Factor)
+	return v, q.unit.Name, q.unit.LaTeX
+}
+
+// String returns the Quantity, like 16/5 m
+func (q *Quantity) String() string {
+	v, name, _ := q.displayed()
+	if name == "" {
+		return v.String()
+	}
+	return v.String() + " " + name
+}
+
+// LaTeX returns the LaTeX representation of the Quantity, like \frac{16}{5}\,\mathrm{m}
+func (q *Quantity) LaTeX() string {
+	v, _, latex := q.displayed()
+	s := FractionToReal(v).LaTeX()
+	if latex == "" {
+		return s
+	}
+	return s + `\,` + latex
+}
+
+// CanBeRepresentedExactly returns true if the value displayed can be exactly represented with the given precision
+func (q *Quantity) CanBeRepresentedExactly(precision int) bool {
+	v, _, _ := q.displayed()
+	return v.CanBeRepresentedExactly(precision)
+}
+
+// Approx returns the decimal approximation of the Quantity, like 3.2 m
+func (q *Quantity) Approx(precision int) string {
+	return q.ApproxWith(precision, Rounding{})
+}
+
+// ApproxWith returns the decimal approximation of the Quantity rounded with r
+func (q *Quantity) ApproxWith(precision int, r Rounding) string {
+	v, name, _ := q.displayed()
+	if name == "" {
+		return v.ApproxWith(precision, r)
+	}
+	return v.ApproxWith(precision, r) + " " + name
+}
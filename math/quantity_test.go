@@ -0,0 +1,150 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+// unitQuantity returns the Quantity n units
+func unitQuantity(t *testing.T, n *Fraction, name string) *Quantity {
+	u, ok := ParseUnit(name)
+	if !ok {
+		t.Fatalf("unknown unit %s", name)
+	}
+	return NewQuantity(n.Mul(u.Factor), u.Dim)
+}
+
+func TestParseUnit(t *testing.T) {
+	genericTest := func(name, factor string, dim Dimension, latex string) {
+		u, ok := ParseUnit(name)
+		if !ok {
+			t.Fatalf("unknown unit %s", name)
+		}
+		if u.Factor.String() != factor || u.Dim != dim || u.LaTeX != latex {
+			t.Errorf("%s: got %s %v %s; want %s %v %s", name, u.Factor, u.Dim, u.LaTeX, factor, dim, latex)
+		}
+	}
+	genericTest("m", "1", Dimension{1}, `\mathrm{m}`)
+	genericTest("cm", "1/100", Dimension{1}, `\mathrm{cm}`)
+	genericTest("kg", "1", Dimension{0, 1}, `\mathrm{kg}`)
+	genericTest("ms", "1/1000", Dimension{0, 0, 1}, `\mathrm{ms}`)
+	genericTest("cd", "1", Dimension{0, 0, 0, 0, 0, 0, 1}, `\mathrm{cd}`)
+	genericTest("kPa", "1000", Dimension{-1, 1, -2}, `\mathrm{kPa}`)
+	genericTest("μA", "1/1000000", Dimension{0, 0, 0, 1}, `\mathrm{\mu A}`)
+	genericTest("h", "3600", Dimension{0, 0, 1}, `\mathrm{h}`)
+	genericTest("min", "60", Dimension{0, 0, 1}, `\mathrm{min}`)
+	for _, name := range []string{"x", "kh", "mmm", "Km"} {
+		if _, ok := ParseUnit(name); ok {
+			t.Errorf("%s must not be a unit", name)
+		}
+	}
+}
+
+func TestQuantity_Arithmetic(t *testing.T) {
+	m := unitQuantity(t, IntToFraction(3), "m")
+	cm := unitQuantity(t, IntToFraction(20), "cm")
+	sum, err := m.Add(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "16/5 m" || sum.Approx(2) != "3.2 m" || sum.LaTeX() != `\frac{16}{5}\,\mathrm{m}` {
+		t.Errorf("got %s, %s and %s", sum, sum.Approx(2), sum.LaTeX())
+	}
+	s := unitQuantity(t, OneFraction, "s")
+	if _, err = m.Sub(s); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("excepted dimension mismatch, got %v", err)
+	}
+	speed, err := m.Div(s.Mul(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	force := speed.Mul(unitQuantity(t, IntToFraction(2), "kg"))
+	if force.String() != "6 m kg/s^2" {
+		t.Errorf("got %s; want 6 m kg/s^2", force)
+	}
+	inv, err := NewQuantity(OneFraction, Dimension{}).Div(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.String() != "1 s^-1" {
+		t.Errorf("got %s; want 1 s^-1", inv)
+	}
+	area, err := m.Exp(IntToFraction(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	side, err := area.Exp(NewFraction(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !side.Value.Is(IntToFraction(3)) || side.Dim != m.Dim {
+		t.Errorf("got %s; want 3 m", side)
+	}
+	if _, err = NewQuantity(IntToFraction(4), m.Dim).Exp(NewFraction(1, 2)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("excepted dimension mismatch, got %v", err)
+	}
+}
+
+func TestQuantity_In(t *testing.T) {
+	speed, err := unitQuantity(t, IntToFraction(100), "km").Div(unitQuantity(t, OneFraction, "h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mps := &Unit{Name: "m/s", LaTeX: `\mathrm{m}/\mathrm{s}`, Factor: OneFraction, Dim: Dimension{1, 0, -1}}
+	q, err := speed.In(mps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.String() != "250/9 m/s" || !q.Value.Is(speed.Value) {
+		t.Errorf("got %s; want 250/9 m/s", q)
+	}
+	km, _ := ParseUnit("km")
+	if _, err = speed.In(km); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("excepted dimension mismatch, got %v", err)
+	}
+	_, err = NewQuantity(IntToFraction(3), Dimension{}).In(km)
+	if !errors.Is(err, ErrDimensionMismatch) || err.Error() != "dimension mismatch\n3 has no unit: it cannot be converted in km" {
+		t.Errorf("excepted dimension mismatch, got %v", err)
+	}
+}
+
+func TestQuantity_DisplayedUnit(t *testing.T) {
+	unit := func(name string) *Quantity {
+		u, ok := ParseUnit(name)
+		if !ok {
+			t.Fatalf("unknown unit %s", name)
+		}
+		return u.Quantity()
+	}
+	three := NewQuantity(IntToFraction(3), Dimension{})
+	h := three.Mul(unit("h"))
+	if h.String() != "3 h" || !h.Value.Is(IntToFraction(10800)) {
+		t.Errorf("got %s; want 3 h", h)
+	}
+	sum, err := h.Add(unit("min").Mul(NewQuantity(IntToFraction(30), Dimension{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "7/2 h" {
+		t.Errorf("got %s; want 7/2 h", sum)
+	}
+	speed, err := three.Mul(unit("km")).Div(unit("h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if speed.String() != "3 km/h" || speed.LaTeX() != `3\,\frac{\mathrm{km}}{\mathrm{h}}` {
+		t.Errorf("got %s and %s; want 3 km/h", speed, speed.LaTeX())
+	}
+	// the units of a product are the SI base units
+	if p := unit("km").Mul(unit("h")); p.String() != "3600000 m s" {
+		t.Errorf("got %s; want 3600000 m s", p)
+	}
+
+	c, err := unit("km").Cmp(unit("m"))
+	if err != nil || c != 1 {
+		t.Errorf("got %d, %v; want 1", c, err)
+	}
+	if _, err = unit("km").Cmp(unit("s")); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("excepted dimension mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+)
+
+const (
+	// maxTrialDivisor is the biggest prime tested when extracting the perfect powers of a radicand
+	maxTrialDivisor = 1 << 16
+)
+
+// radical is the atom radicand^(1/index), like sqrt(2) or root(3, 4).
+// The radicand is a positive integer not containing any perfect power of index (as far as the trial division goes).
+type radical struct {
+	index    int64
+	radicand *big.Int
+}
+
+func (a *radical) key() string {
+	return a.String()
+}
+
+func (a *radical) String() string {
+	if a.index == 2 {
+		return "sqrt(" + a.radicand.String() + ")"
+	}
+	return fmt.Sprintf("root(%d, %s)", a.index, a.radicand)
+}
+
+func (a *radical) LaTeX() string {
+	if a.index == 2 {
+		return `\sqrt{` + a.radicand.String() + "}"
+	}
+	return fmt.Sprintf(`\sqrt[%d]{%s}`, a.index, a.radicand)
+}
+
+func (a *radical) approx(prec uint) *big.Float {
+	x := new(big.Float).SetPrec(prec + guardBits).SetInt(a.radicand)
+	if a.index == 2 {
+		return sqrtFloat(x, prec)
+	}
+	return nthRootFloat(x, a.index, prec)
+}
+
+// nthRootFloat computes x^(1/n) = exp(ln(x)/n), with x > 0
+func nthRootFloat(x *big.Float, n int64, prec uint) *big.Float {
+	wp := prec + guardBits
+	l := lnFloat(x, wp)
+	return expFloat(l.Quo(l, new(big.Float).SetInt64(n)), prec)
+}
+
+// radicalToReal returns the Real x^(1/n), with x a positive integer
+func radicalToReal(x *big.Int, n int64) *Real {
+	outside, inside, index := simplifyRadical(x, n)
+	t := &term{coef: &Fraction{new(big.Rat).SetInt(outside)}}
+	if inside.Cmp(big.NewInt(1)) != 0 {
+		t.factors = []*factor{{&radical{index, inside}, 1}}
+	}
+	return newReal(t)
+}
+
+// normalizeRadicals simplifies the radicals of the term.
+//
+// Radicals sharing the same index are merged, their exponents are reduced in [0 ; index[ and the perfect powers of
+// the radicands are moved into the coefficient.
+// So sqrt(2)*sqrt(2) becomes 2, sqrt(2)*sqrt(6) becomes 2sqrt(3) and 1/sqrt(2) becomes sqrt(2)/2.
+func (t *term) normalizeRadicals() *term {
+	coef := t.coef
+	products := map[int64]*big.Int{}
+	single := map[int64]*radical{}
+	var indexes []int64
+	var factors []*factor
+	for _, f := range t.factors {
+		r, ok := f.atom.(*radical)
+		if !ok {
+			factors = append(factors, f)
+			continue
+		}
+		// radicand^(exp/index) = radicand^q * radicand^(rest/index)
+		q, rest := f.exp/r.index, f.exp%r.index
+		if rest < 0 {
+			q--
+			rest += r.index
+		}
+		coef = coef.Mul(intPow(r.radicand, q))
+		if rest == 0 {
+			continue
+		}
+		p, ok := products[r.index]
+		if !ok {
+			p = big.NewInt(1)
+			products[r.index] = p
+			indexes = append(indexes, r.index)
+			if rest == 1 {
+				single[r.index] = r
+			}
+		} else {
+			single[r.index] = nil
+		}
+		p.Mul(p, new(big.Int).Exp(r.radicand, big.NewInt(rest), nil))
+	}
+	for _, n := range indexes {
+		if single[n] != nil {
+			// a radical alone is already simplified
+			factors = append(factors, &factor{single[n], 1})
+			continue
+		}
+		outside, inside, index := simplifyRadical(products[n], n)
+		coef = coef.Mul(&Fraction{new(big.Rat).SetInt(outside)})
+		if inside.Cmp(big.NewInt(1)) != 0 {
+			factors = append(factors, &factor{&radical{index, inside}, 1})
+		}
+	}
+	return &term{coef, factors}
+}
+
+// intPow returns x^n as a Fraction, n can be negative
+func intPow(x *big.Int, n int64) *Fraction {
+	p := new(big.Int).Exp(x, big.NewInt(abs(n)), nil)
+	if n < 0 {
+		return &Fraction{new(big.Rat).SetFrac(big.NewInt(1), p)}
+	}
+	return &Fraction{new(big.Rat).SetInt(p)}
+}
+
+// simplifyRadical returns outside, inside and index such that x^(1/n) = outside * inside^(1/index)
+func simplifyRadical(x *big.Int, n int64) (*big.Int, *big.Int, int64) {
+	outside, inside := extractPower(x, n)
+	// root(4, 4) is sqrt(2)
+	for d := n; d > 1; d-- {
+		if n%d != 0 {
+			continue
+		}
+		if r, ok := intRoot(inside, d); ok {
+			inside = r
+			n /= d
+		}
+	}
+	return outside, inside, n
+}
+
+// extractPower returns outside and inside such that x = outside^n * inside, with x > 0
+func extractPower(x *big.Int, n int64) (*big.Int, *big.Int) {
+	outside := big.NewInt(1)
+	inside := big.NewInt(1)
+	rest := new(big.Int).Set(x)
+	// a prime greater than limit cannot divide rest n times
+	limit, _ := intRoot(rest, n)
+	m := new(big.Int)
+	q := new(big.Int)
+	r := new(big.Int)
+	for _, block := range trialPrimes() {
+		if limit.Cmp(big.NewInt(int64(block.primes[0]))) < 0 {
+			break
+		}
+		// one division by the product of the primes tells which ones divide rest
+		res := r.Mod(rest, block.product).Uint64()
+		for _, p := range block.primes {
+			if res%p != 0 {
+				continue
+			}
+			bp := new(big.Int).SetUint64(p)
+			count := int64(0)
+			for {
+				q.DivMod(rest, bp, m)
+				if m.Sign() != 0 {
+					break
+				}
+				rest.Set(q)
+				count++
+			}
+			outside.Mul(outside, new(big.Int).Exp(bp, big.NewInt(count/n), nil))
+			inside.Mul(inside, new(big.Int).Exp(bp, big.NewInt(count%n), nil))
+			limit, _ = intRoot(rest, n)
+		}
+	}
+	if r, ok := intRoot(rest, n); ok {
+		return outside.Mul(outside, r), inside
+	}
+	return outside, inside.Mul(inside, rest)
+}
+
+// primeBlock is a group of consecutive primes whose product fits in an uint64
+type primeBlock struct {
+	primes  []uint64
+	product *big.Int
+}
+
+var trialPrimes = sync.OnceValue(func() []*primeBlock {
+	composite := make([]bool, maxTrialDivisor+1)
+	var blocks []*primeBlock
+	cur := &primeBlock{}
+	prod := uint64(1)
+	for p := uint64(2); p <= maxTrialDivisor; p++ {
+		if composite[p] {
+			continue
+		}
+		for k := p * p; k <= maxTrialDivisor; k += p {
+			composite[k] = true
+		}
+		if prod > math.MaxUint64/p {
+			cur.product = new(big.Int).SetUint64(prod)
+			blocks = append(blocks, cur)
+			cur, prod = &primeBlock{}, 1
+		}
+		cur.primes = append(cur.primes, p)
+		prod *= p
+	}
+	cur.product = new(big.Int).SetUint64(prod)
+	return append(blocks, cur)
+})
+
+// intRoot returns the integer n-th root of x >= 0 and true if it is exact
+func intRoot(x *big.Int, n int64) (*big.Int, bool) {
+	if x.Sign() == 0 || n == 1 {
+		return new(big.Int).Set(x), true
+	}
+	var r *big.Int
+	if n == 2 {
+		r = new(big.Int).Sqrt(x)
+	} else {
+		// Newton's method, starting from a value greater than the root
+		bn := big.NewInt(n)
+		r = new(big.Int).Lsh(big.NewInt(1), uint(x.BitLen()/int(n)+1))
+		for {
+			// next = ((n-1)r + x/r^(n-1)) / n
+			next := new(big.Int).Exp(r, big.NewInt(n-1), nil)
+			next.Quo(x, next)
+			next.Add(next, new(big.Int).Mul(r, big.NewInt(n-1)))
+			next.Quo(next, bn)
+			if next.Cmp(r) >= 0 {
+				break
+			}
+			r = next
+		}
+	}
+	return r, new(big.Int).Exp(r, big.NewInt(n), nil).Cmp(x) == 0
+}
+
+// Root returns the n-th root of r.
+// Returns ErrIllegalOperation if n is not strictly positive or if r is negative and n is even.
+//
+// Rational roots are simplified exactly: root(3, 16) is 2root(3, 2) and root(2, 9/4) is 3/2.
+func Root(n int64, r *Real) (*Real, error) {
+	if n <= 0 {
+		return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("root of index %d is not defined", n))
+	}
+	if n == 1 {
+		return r, nil
+	}
+	sign := r.Sign()
+	if sign < 0 {
+		if n%2 == 0 {
+			return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("root(%d, %s) is not defined", n, r))
+		}
+		res, err := Root(n, r.Neg())
+		if err != nil {
+			return nil, err
+		}
+		return res.Neg(), nil
+	}
+	if sign == 0 && r.IsRational() {
+		return NullReal, nil
+	}
+	if f, ok := r.Fraction(); ok {
+		// root(n, p/q) = root(n, p*q^(n-1))/q
+		x := new(big.Int).Exp(f.Denom(), big.NewInt(n-1), nil)
+		x.Mul(x, f.Num())
+		res := radicalToReal(x, n)
+		return res.Div(FractionToReal(&Fraction{new(big.Rat).SetInt(f.Denom())}))
+	}
+	if res, ok := r.monomialRoot(n); ok {
+		return res, nil
+	}
+	if n == 2 {
+		return applicationToReal("sqrt", `\sqrt{%s}`, r, sqrtFloat), nil
+	}
+	a := &application{
+		name:   "root",
+		format: fmt.Sprintf("root(%d, %%s)", n),
+		latex:  fmt.Sprintf(`\sqrt[%d]{%%s}`, n),
+		arg:    r,
+		fn: func(x *big.Float, prec uint) *big.Float {
+			return nthRootFloat(x, n, prec)
+		},
+	}
+	return newReal(&term{OneFraction, []*factor{{a, 1}}}), nil
+}
+
+// monomialRoot returns the n-th root of the Real if it is a positive monomial whose exponents are multiples of n,
+// like 4pi^2
+func (r *Real) monomialRoot(n int64) (*Real, bool) {
+	if len(r.terms) != 1 || r.terms[0].coef.Sign() < 0 {
+		return nil, false
+	}
+	t := r.terms[0]
+	factors := make([]*factor, len(t.factors))
+	for i, f := range t.factors {
+		if _, ok := f.atom.(*radical); ok || f.exp%n != 0 {
+			return nil, false
+		}
+		factors[i] = &factor{f.atom, f.exp / n}
+	}
+	c, err := Root(n, FractionToReal(t.coef))
+	if err != nil {
+		return nil, false
+	}
+	return c.Mul(newReal(&term{OneFraction, factors})), true
+}
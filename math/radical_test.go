@@ -0,0 +1,80 @@
+package math
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRoot(t *testing.T) {
+	genericTest := func(n int64, r *Real, expected string) {
+		res, err := Root(n, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.String() != expected {
+			t.Errorf("root(%d, %s): got %s; want %s", n, r, res, expected)
+		}
+	}
+	genericTest(2, IntToReal(8), "2sqrt(2)")
+	genericTest(2, FractionToReal(NewFraction(9, 4)), "3/2")
+	genericTest(2, FractionToReal(NewFraction(2, 3)), "sqrt(6)/3")
+	genericTest(3, IntToReal(16), "2root(3, 2)")
+	genericTest(3, IntToReal(-8), "-2")
+	genericTest(4, IntToReal(4), "sqrt(2)")
+	genericTest(6, IntToReal(8), "sqrt(2)")
+	genericTest(2, Pi.Mul(Pi).Mul(IntToReal(4)), "2pi")
+	genericTest(2, Pi, "sqrt(pi)")
+	genericTest(3, Pi, "root(3, pi)")
+
+	if _, err := Root(2, IntToReal(-4)); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	} else if !strings.Contains(err.Error(), "root(2, -4) is not defined") {
+		t.Errorf("the error must show the root, got %v", err)
+	}
+	if _, err := Root(0, IntToReal(4)); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+}
+
+func TestRadical_Arithmetic(t *testing.T) {
+	sqrt := func(n int64) *Real {
+		r, err := Sqrt(IntToReal(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	genericTest := func(r *Real, expected string) {
+		if r.String() != expected {
+			t.Errorf("got %s; want %s", r, expected)
+		}
+	}
+	genericTest(sqrt(2).Mul(sqrt(2)), "2")
+	genericTest(sqrt(2).Mul(sqrt(6)), "2sqrt(3)")
+	genericTest(sqrt(12).Add(sqrt(27)), "5sqrt(3)")
+	genericTest(sqrt(2).Add(OneReal).Mul(sqrt(2).Add(OneReal)), "3 + 2sqrt(2)")
+	inv, err := sqrt(2).Inv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(inv, "sqrt(2)/2")
+	inv, err = sqrt(2).Add(OneReal).Inv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(inv, "-1 + sqrt(2)")
+	cbrt, err := Root(3, IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(cbrt.Mul(cbrt), "root(3, 4)")
+	genericTest(cbrt.Mul(cbrt).Mul(cbrt), "2")
+
+	if got, expected := sqrt(8).LaTeX(), `2\sqrt{2}`; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+	if got, expected := cbrt.Approx(20), "1.25992104989487316477"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+}
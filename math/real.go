@@ -0,0 +1,678 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// Real is an exact real number.
+// It is a sum of terms, each term being a Fraction multiplied by a product of irrational factors, like 3pi/2 or
+// 1 + e^2.
+// Irrational factors are kept symbolic: digits are only computed by Approx, with the requested precision.
+type Real struct {
+	terms []*term
+}
+
+// term is a Fraction multiplied by a product of factors
+type term struct {
+	coef    *Fraction
+	factors []*factor
+}
+
+// factor is an atom raised to a non-null integer power
+type factor struct {
+	atom atom
+	exp  int64
+}
+
+// atom is an irrational number kept symbolic
+type atom interface {
+	// key identifies the atom
+	key() string
+	// String returns the representation of the atom
+	String() string
+	// LaTeX returns the LaTeX representation of the atom
+	LaTeX() string
+	// approx returns an approximation of the atom with a mantissa of prec bits
+	approx(prec uint) *big.Float
+}
+
+const (
+	// guardBits are the bits added to the working precision to absorb rounding errors
+	guardBits = 64
+	// maxSignPrecision is the maximum precision used to determine the sign of a Real
+	maxSignPrecision = 1 << 14
+	// maxMagnitude is the maximum number of bits of the integer part of an irrational power, like e^90000: the
+	// approximations of larger ones take too long
+	maxMagnitude = 1 << 17
+)
+
+var (
+	NullReal = IntToReal(0)
+	OneReal  = IntToReal(1)
+)
+
+// FractionToReal converts a Fraction into a Real
+func FractionToReal(f *Fraction) *Real {
+	return newReal(&term{coef: f})
+}
+
+// IntToReal converts an int64 into a Real
+func IntToReal(n int64) *Real {
+	return FractionToReal(IntToFraction(n))
+}
+
+// newReal returns the simplified sum of the terms
+func newReal(terms ...*term) *Real {
+	var keys []string
+	byKey := map[string]*term{}
+	for _, t := range terms {
+		t = t.normalize()
+		k := t.key()
+		if c, ok := byKey[k]; ok {
+			byKey[k] = &term{c.coef.Add(t.coef), c.factors}
+		} else {
+			byKey[k] = t
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	r := &Real{}
+	for _, k := range keys {
+		if t := byKey[k]; t.coef.Sign() != 0 {
+			r.terms = append(r.terms, t)
+		}
+	}
+	return r
+}
+
+// normalize sorts the factors of the term, merges the ones having the same atom and simplifies the radicals
+func (t *term) normalize() *term {
+	var factors []*factor
+	for _, f := range t.factors {
+		i := slices.IndexFunc(factors, func(g *factor) bool { return g.atom.key() == f.atom.key() })
+		if i == -1 {
+			factors = append(factors, f)
+		} else {
+			factors[i] = &factor{f.atom, factors[i].exp + f.exp}
+		}
+	}
+	n := (&term{t.coef, factors}).normalizeRadicals()
+	n.factors = slices.DeleteFunc(n.factors, func(f *factor) bool { return f.exp == 0 })
+	slices.SortFunc(n.factors, func(a, b *factor) int { return strings.Compare(a.atom.key(), b.atom.key()) })
+	return n
+}
+
+// key identifies the product of factors of the term
+func (t *term) key() string {
+	keys := make([]string, len(t.factors))
+	for i, f := range t.factors {
+		keys[i] = fmt.Sprintf("%s^%d", f.atom.key(), f.exp)
+	}
+	return strings.Join(keys, "*")
+}
+
+func (t *term) mul(a *term) *term {
+	return &term{t.coef.Mul(a.coef), append(slices.Clone(t.factors), a.factors...)}
+}
+
+func (t *term) approx(prec uint) *big.Float {
+	v := new(big.Float).SetPrec(prec).SetRat(t.coef.Rat)
+	for _, f := range t.factors {
+		n := abs(f.exp)
+		// each multiplication adds a rounding error, log2(n) more bits absorb them
+		wp := prec + uint(bits.Len64(uint64(n)))
+		p := powFloat(f.atom.approx(wp), n, wp)
+		if f.exp > 0 {
+			v.Mul(v, p)
+		} else {
+			v.Quo(v, p)
+		}
+	}
+	return v
+}
+
+// powFloat computes x^n, with n > 0
+func powFloat(x *big.Float, n int64, prec uint) *big.Float {
+	res := new(big.Float).SetPrec(prec).SetInt64(1)
+	base := new(big.Float).SetPrec(prec).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			res.Mul(res, base)
+		}
+		base.Mul(base, base)
+	}
+	return res
+}
+
+// Fraction returns the Fraction represented by the Real.
+// Returns false if the Real is irrational.
+func (r *Real) Fraction() (*Fraction, bool) {
+	switch len(r.terms) {
+	case 0:
+		return NullFraction, true
+	case 1:
+		if len(r.terms[0].factors) == 0 {
+			return r.terms[0].coef, true
+		}
+	}
+	return nil, false
+}
+
+// IsRational returns true if the Real is a Fraction
+func (r *Real) IsRational() bool {
+	_, ok := r.Fraction()
+	return ok
+}
+
+// Is returns true if both Real are structurally equal
+func (r *Real) Is(a *Real) bool {
+	return r.Sub(a).IsNull()
+}
+
+// IsNull returns true if the Real is equal to 0
+func (r *Real) IsNull() bool {
+	return len(r.terms) == 0
+}
+
+// Add a Real
+func (r *Real) Add(a *Real) *Real {
+	return newReal(append(slices.Clone(r.terms), a.terms...)...)
+}
+
+// Neg returns the opposite of the Real
+func (r *Real) Neg() *Real {
+	terms := make([]*term, len(r.terms))
+	for i, t := range r.terms {
+		terms[i] = &term{t.coef.Neg(), t.factors}
+	}
+	return &Real{terms}
+}
+
+// Sub (subtract) a Real
+func (r *Real) Sub(a *Real) *Real {
+	return r.Add(a.Neg())
+}
+
+// Mul (multiply) by a Real
+func (r *Real) Mul(a *Real) *Real {
+	var terms []*term
+	for _, t := range r.terms {
+		for _, u := range a.terms {
+			terms = append(terms, t.mul(u))
+		}
+	}
+	return newReal(terms...)
+}
+
+// Inv (invert) the Real
+func (r *Real) Inv() (*Real, error) {
+	if r.IsNull() {
+		return r, errors.Join(ErrIllegalOperation, errors.New("cannot invert a null Real"))
+	}
+	if inv, ok := r.conjugateInv(); ok {
+		return inv, nil
+	}
+	if len(r.terms) > 1 {
+		// the first coefficient is put outside the reciprocal to have a unique representation of it
+		c := r.terms[0].coef
+		s, err := r.Div(FractionToReal(c))
+		if err != nil {
+			return nil, err
+		}
+		inv, _ := c.Inv()
+		return newReal(&term{inv, []*factor{{&reciprocal{s}, 1}}}), nil
+	}
+	t := r.terms[0]
+	coef, _ := t.coef.Inv()
+	res := newReal(&term{coef: coef})
+	for _, f := range t.factors {
+		if rec, ok := f.atom.(*reciprocal); ok {
+			res = res.Mul(rec.r.intExp(f.exp))
+		} else {
+			res = res.Mul(newReal(&term{OneFraction, []*factor{{f.atom, -f.exp}}}))
+		}
+	}
+	return res, nil
+}
+
+// conjugateInv returns 1/r if r = a + b*sqrt(n), using the conjugate: 1/r = (a - b*sqrt(n))/(a^2 - b^2 n)
+func (r *Real) conjugateInv() (*Real, bool) {
+	if len(r.terms) != 2 || len(r.terms[0].factors) != 0 || len(r.terms[1].factors) != 1 {
+		return nil, false
+	}
+	f := r.terms[1].factors[0]
+	rad, ok := f.atom.(*radical)
+	if !ok || rad.index != 2 || f.exp != 1 {
+		return nil, false
+	}
+	a, b := r.terms[0].coef, r.terms[1].coef
+	n := &Fraction{new(big.Rat).SetInt(rad.radicand)}
+	// a^2 - b^2 n is not null because sqrt(n) is irrational
+	den := a.Mul(a).Sub(b.Mul(b).Mul(n))
+	conj := newReal(r.terms[0], &term{b.Neg(), r.terms[1].factors})
+	inv, err := conj.Div(FractionToReal(den))
+	return inv, err == nil
+}
+
+// Div (divide) by a Real
+func (r *Real) Div(a *Real) (*Real, error) {
+	if f, ok := a.Fraction(); ok {
+		inv, err := f.Inv()
+		if err != nil {
+			return r, errors.Join(err, errors.New("cannot divide by a null Real"))
+		}
+		return r.Mul(FractionToReal(inv)), nil
+	}
+	inv, err := a.Inv()
+	if err != nil {
+		return r, errors.Join(err, errors.New("cannot divide by a null Real"))
+	}
+	return r.Mul(inv), nil
+}
+
+// Exp the Real by another.
+//
+// The exponent must be rational: r^(p/q) is the q-th root of r^p and it is kept exact, so 8^(2/3) is 4 and 2^(3/2) is
+// 2sqrt(2).
+// Returns ErrIllegalOperation if the result is not defined, like (-8)^(1/2) or 0^-1, and ErrUnsupportedOperation if
+// it is irrational and too large to be approximated, like e^(10^6).
+func (r *Real) Exp(a *Real) (*Real, error) {
+	n, ok := a.Fraction()
+	if !ok {
+		return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("Real.Exp(%s) is not supported because it's not rational", a))
+	}
+	if f, ok := r.Fraction(); ok {
+		res, err := f.Exp(n)
+		if err == nil {
+			return FractionToReal(res), nil
+		}
+		if !errors.Is(err, ErrIrrationalResult) {
+			return nil, err
+		}
+	}
+	if !n.IsInt() {
+		q := n.Denom()
+		if !q.IsInt64() {
+			return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("root of index %s is too big", q))
+		}
+		if q.Bit(0) == 0 && r.Sign() < 0 {
+			return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("(%s)^(%s) is not defined", r, n))
+		}
+		root, err := Root(q.Int64(), r)
+		if err != nil {
+			return nil, err
+		}
+		return root.Exp(FractionToReal(&Fraction{new(big.Rat).SetInt(n.Num())}))
+	}
+	i, _ := n.Int()
+	if !i.IsInt64() {
+		return nil, errors.Join(ErrUnsupportedOperation, fmt.Errorf("exponent %s is too big", i))
+	}
+	if math.Log2(math.Abs(r.Float()))*float64(i.Int64()) > maxMagnitude {
+		base := r.String()
+		if strings.ContainsAny(base, " /") {
+			base = "(" + base + ")"
+		}
+		return nil, genErrTooLarge(base + "^" + i.String())
+	}
+	if i.Sign() < 0 {
+		inv, err := r.Inv()
+		if err != nil {
+			return nil, err
+		}
+		return inv.intExp(-i.Int64()), nil
+	}
+	return r.intExp(i.Int64()), nil
+}
+
+// Mod returns the remainder of the floored division of the Real by a, r - a*floor(r/a).
+// The remainder has the sign of a (see Fraction.Mod) and it stays exact, so 7 mod pi is 7 - 2pi.
+// Returns ErrIllegalOperation if a is null.
+func (r *Real) Mod(a *Real) (*Real, error) {
+	if f, ok := r.Fraction(); ok {
+		if g, ok := a.Fraction(); ok {
+			res, err := f.Mod(g)
+			if err != nil {
+				return nil, err
+			}
+			return FractionToReal(res), nil
+		}
+	}
+	q, err := r.Div(a)
+	if err != nil {
+		return nil, errors.Join(err, fmt.Errorf("%s mod 0 is not defined", r))
+	}
+	return r.Sub(a.Mul(FractionToReal(q.floor()))), nil
+}
+
+// floor returns the biggest integer smaller than or equal to the Real
+func (r *Real) floor() *Fraction {
+	if f, ok := r.Fraction(); ok {
+		return f.floor()
+	}
+	v := r.approx(guardBits)
+	// the working precision must include the integer part
+	if e := v.MantExp(nil); e > 0 {
+		v = r.approx(guardBits + uint(e))
+	}
+	n, _ := v.Int(nil)
+	f := &Fraction{new(big.Rat).SetInt(n)}
+	// the approximation can be wrong near an integer, the exact sign is used to fix it
+	if r.Sub(FractionToReal(f)).Sign() < 0 {
+		return f.Sub(OneFraction)
+	}
+	if next := f.Add(OneFraction); r.Sub(FractionToReal(next)).Sign() >= 0 {
+		return next
+	}
+	return f
+}
+
+func genErrTooLarge(s string) error {
+	return errors.Join(ErrUnsupportedOperation, fmt.Errorf("%s is too large to be approximated", s))
+}
+
+// intExp returns the Real raised to the positive power n
+func (r *Real) intExp(n int64) *Real {
+	if len(r.terms) == 1 {
+		t := r.terms[0]
+		coef, _ := t.coef.Exp(IntToFraction(n))
+		factors := make([]*factor, len(t.factors))
+		for i, f := range t.factors {
+			factors[i] = &factor{f.atom, f.exp * n}
+		}
+		return newReal(&term{coef, factors})
+	}
+	res := OneReal
+	base := r
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			res = res.Mul(base)
+		}
+		base = base.Mul(base)
+	}
+	return res
+}
+
+// Sign returns -1 if the Real is negative, 0 if it is null and +1 if it is positive.
+//
+// The sign of an irrational Real is determined by approximating it.
+// If the Real is too close to 0 to be distinguished from it, 0 is returned.
+func (r *Real) Sign() int {
+	if f, ok := r.Fraction(); ok {
+		return f.Sign()
+	}
+	for prec := uint(guardBits); prec <= maxSignPrecision; prec *= 2 {
+		v := new(big.Float).SetPrec(prec)
+		maxExp := math.MinInt
+		for _, t := range r.terms {
+			a := t.approx(prec + guardBits)
+			maxExp = max(maxExp, a.MantExp(nil))
+			v.Add(v, a)
+		}
+		// the error of the approximation is smaller than 2^(maxExp - prec)
+		if v.Sign() != 0 && v.MantExp(nil) > maxExp-int(prec) {
+			return v.Sign()
+		}
+	}
+	return 0
+}
+
+// Cmp compares the Real with another one.
+// Returns -1 if r < a, 0 if r = a and +1 if r > a.
+func (r *Real) Cmp(a *Real) int {
+	return r.Sub(a).Sign()
+}
+
+func (r *Real) SmallerOrEqualThan(a *Real) bool {
+	return r.Cmp(a) <= 0
+}
+
+func (r *Real) SmallerThan(a *Real) bool {
+	return r.Cmp(a) < 0
+}
+
+func (r *Real) GreaterOrEqualThan(a *Real) bool {
+	return r.Cmp(a) >= 0
+}
+
+func (r *Real) GreaterThan(a *Real) bool {
+	return r.Cmp(a) > 0
+}
+
+// Float converts the Real to a float
+func (r *Real) Float() float64 {
+	if f, ok := r.Fraction(); ok {
+		v, _ := f.Float()
+		return v
+	}
+	v, _ := r.approx(guardBits).Float64()
+	return v
+}
+
+// approx returns an approximation of the Real with a mantissa of prec bits
+func (r *Real) approx(prec uint) *big.Float {
+	v := new(big.Float).SetPrec(prec)
+	for _, t := range r.terms {
+		v.Add(v, t.approx(prec+guardBits))
+	}
+	return v
+}
+
+// Approx returns the decimal representation of the Real with the given number of digits after the decimal point.
+// Every digit shown is correct (the last one is rounded).
+func (r *Real) Approx(precision int) string {
+	return r.ApproxWith(precision, Rounding{})
+}
+
+// ApproxWith returns the decimal representation of the Real with the given number of digits after the decimal point,
+// rounded with rnd
+func (r *Real) ApproxWith(precision int, rnd Rounding) string {
+	if f, ok := r.Fraction(); ok {
+		return f.ApproxWith(precision, rnd)
+	}
+	prec := uint(float64(max(precision, 0))*math.Log2(10)) + guardBits
+	s := r.approxString(prec, precision, rnd)
+	// the approximation is correct if it does not change when the working precision grows
+	for ; prec <= maxSignPrecision; prec *= 2 {
+		next := r.approxString(2*prec, precision, rnd)
+		if next == s {
+			return s
+		}
+		s = next
+	}
+	return s
+}
+
+func (r *Real) approxString(prec uint, precision int, rnd Rounding) string {
+	return r.approxFraction(prec).ApproxWith(precision, rnd)
+}
+
+// approxFraction returns an approximation of the Real with prec bits after the binary point
+func (r *Real) approxFraction(prec uint) *Fraction {
+	v := r.approx(prec)
+	// the working precision must include the integer part
+	if e := v.MantExp(nil); e > 0 {
+		v = r.approx(prec + uint(e))
+	}
+	rat, _ := v.Rat(nil)
+	return &Fraction{rat}
+}
+
+// Round the Real to the given number of decimals with the given mode, like Fraction.Round
+func (r *Real) Round(decimals int, mode RoundingMode) *Fraction {
+	if f, ok := r.Fraction(); ok {
+		return f.Round(decimals, mode)
+	}
+	prec := uint(float64(max(decimals, 0))*math.Log2(10)) + guardBits
+	f := r.approxFraction(prec).Round(decimals, mode)
+	// the rounding is correct if it does not change when the working precision grows
+	for ; prec <= maxSignPrecision; prec *= 2 {
+		next := r.approxFraction(2*prec).Round(decimals, mode)
+		if next.Cmp(f.Rat) == 0 {
+			return f
+		}
+		f = next
+	}
+	return f
+}
+
+// CanBeRepresentedExactly returns true if the Real is rational and can be exactly represented with the given
+// precision
+func (r *Real) CanBeRepresentedExactly(precision int) bool {
+	f, ok := r.Fraction()
+	return ok && f.CanBeRepresentedExactly(precision)
+}
+
+func (r *Real) String() string {
+	if len(r.terms) == 0 {
+		return "0"
+	}
+	s := ""
+	for i, t := range r.terms {
+		ts := t.String()
+		if i == 0 {
+			s = ts
+		} else if strings.HasPrefix(ts, "-") {
+			s += " - " + ts[1:]
+		} else {
+			s += " + " + ts
+		}
+	}
+	return s
+}
+
+func (t *term) String() string {
+	if len(t.factors) == 0 {
+		return t.coef.String()
+	}
+	var num, den []string
+	for _, f := range t.factors {
+		s := f.atom.String()
+		if f.exp != 1 && f.exp != -1 {
+			s = fmt.Sprintf("%s^%d", s, abs(f.exp))
+		}
+		if f.inDenominator() {
+			den = append(den, s)
+		} else {
+			num = append(num, s)
+		}
+	}
+	s := ""
+	if t.coef.Sign() < 0 {
+		s = "-"
+	}
+	n := new(big.Int).Abs(t.coef.Num())
+	if len(num) == 0 || !isOne(n) {
+		s += n.String()
+	}
+	s += strings.Join(num, "*")
+	if !isOne(t.coef.Denom()) {
+		den = append([]string{t.coef.Denom().String()}, den...)
+	}
+	switch len(den) {
+	case 0:
+		return s
+	case 1:
+		return s + "/" + den[0]
+	}
+	return s + "/(" + strings.Join(den, "*") + ")"
+}
+
+// LaTeX returns the LaTeX representation of the Real
+func (r *Real) LaTeX() string {
+	if len(r.terms) == 0 {
+		return "0"
+	}
+	s := ""
+	for i, t := range r.terms {
+		ts := t.LaTeX()
+		if i == 0 {
+			s = ts
+		} else if strings.HasPrefix(ts, "-") {
+			s += " - " + ts[1:]
+		} else {
+			s += " + " + ts
+		}
+	}
+	return s
+}
+
+func (t *term) LaTeX() string {
+	var num, den []string
+	for _, f := range t.factors {
+		s := f.atom.LaTeX()
+		if _, ok := f.atom.(*reciprocal); ok && (f.exp != 1 || len(t.factors) > 1 || !isOne(t.coef.Denom())) {
+			s = `\left(` + s + `\right)`
+		}
+		if f.exp != 1 && f.exp != -1 {
+			s = fmt.Sprintf("%s^{%d}", s, abs(f.exp))
+		}
+		if f.inDenominator() {
+			den = append(den, s)
+		} else {
+			num = append(num, s)
+		}
+	}
+	s := ""
+	if t.coef.Sign() < 0 {
+		s = "-"
+	}
+	// the coefficient is written right before the factors, like 2\sqrt{2}
+	n := new(big.Int).Abs(t.coef.Num())
+	numerator := strings.Join(num, " ")
+	if len(num) == 0 || !isOne(n) {
+		numerator = n.String() + numerator
+	}
+	if !isOne(t.coef.Denom()) {
+		den = append([]string{t.coef.Denom().String()}, den...)
+	}
+	if len(den) == 0 {
+		return s + numerator
+	}
+	return fmt.Sprintf(`%s\frac{%s}{%s}`, s, numerator, strings.Join(den, " "))
+}
+
+// inDenominator returns true if the factor is displayed in the denominator
+func (f *factor) inDenominator() bool {
+	_, ok := f.atom.(*reciprocal)
+	return ok == (f.exp > 0)
+}
+
+func isOne(n *big.Int) bool {
+	return n.Cmp(big.NewInt(1)) == 0
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// reciprocal is the atom 1/r, where r is a sum of several terms
+type reciprocal struct {
+	r *Real
+}
+
+func (a *reciprocal) key() string {
+	return "1/(" + a.r.String() + ")"
+}
+
+func (a *reciprocal) String() string {
+	// the reciprocal is displayed in the denominator
+	return "(" + a.r.String() + ")"
+}
+
+func (a *reciprocal) LaTeX() string {
+	return a.r.LaTeX()
+}
+
+func (a *reciprocal) approx(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).Quo(big.NewFloat(1).SetPrec(prec), a.r.approx(prec))
+}
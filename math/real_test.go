@@ -0,0 +1,118 @@
+package math
+
+import (
+	"testing"
+)
+
+func TestReal_String(t *testing.T) {
+	genericTest := func(r *Real, expected string) {
+		if r.String() != expected {
+			t.Errorf("got %s; want %s", r, expected)
+		}
+	}
+	threePiOverTwo, err := Pi.Mul(IntToReal(3)).Div(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(threePiOverTwo, "3pi/2")
+	genericTest(Pi.Neg(), "-pi")
+	genericTest(Pi.Add(OneReal), "1 + pi")
+	genericTest(Pi.Sub(Pi), "0")
+	genericTest(Pi.Mul(Pi), "pi^2")
+	inv, err := Pi.Add(OneReal).Inv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(inv, "1/(1 + pi)")
+	res, err := inv.Mul(Pi.Add(OneReal)).Div(OneReal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsRational() {
+		t.Errorf("%s should not be simplified", res)
+	}
+	genericTest(FractionToReal(NewFraction(3, 4)), "3/4")
+}
+
+func TestReal_LaTeX(t *testing.T) {
+	genericTest := func(r *Real, expected string) {
+		if r.LaTeX() != expected {
+			t.Errorf("got %s; want %s", r.LaTeX(), expected)
+		}
+	}
+	threePiOverTwo, err := Pi.Mul(IntToReal(3)).Div(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(threePiOverTwo, `\frac{3\pi}{2}`)
+	genericTest(Phi.Add(OneReal), `1 + \phi`)
+	genericTest(E.Mul(E).Neg(), `-e^{2}`)
+}
+
+func TestReal_Approx(t *testing.T) {
+	genericTest := func(r *Real, precision int, expected string) {
+		if got := r.Approx(precision); got != expected {
+			t.Errorf("got %s; want %s", got, expected)
+		}
+	}
+	genericTest(Pi, 40, "3.1415926535897932384626433832795028841972")
+	genericTest(E, 30, "2.718281828459045235360287471353")
+	genericTest(Phi, 20, "1.6180339887498948482")
+	genericTest(Pi.Mul(IntToReal(1_000_000)), 5, "3141592.65359")
+	genericTest(FractionToReal(NewFraction(3, 4)), 5, "0.75")
+
+	rounded := func(r *Real, precision int, rnd Rounding, expected string) {
+		if got := r.ApproxWith(precision, rnd); got != expected {
+			t.Errorf("got %s; want %s", got, expected)
+		}
+	}
+	rounded(Pi, 4, Rounding{Mode: Floor}, "3.1415")
+	rounded(Pi, 4, Rounding{Mode: Ceiling}, "3.1416")
+	rounded(Pi.Neg(), 2, Rounding{Mode: Floor}, "-3.15")
+	rounded(Pi.Neg(), 2, Rounding{Mode: TowardZero}, "-3.14")
+	rounded(Phi, 3, Rounding{Mode: HalfDown}, "1.618")
+}
+
+func TestReal_Cmp(t *testing.T) {
+	if !Pi.GreaterThan(IntToReal(3)) {
+		t.Errorf("pi should be greater than 3")
+	}
+	if !Pi.SmallerThan(FractionToReal(NewFraction(22, 7))) {
+		t.Errorf("pi should be smaller than 22/7")
+	}
+	if Pi.Cmp(Pi) != 0 {
+		t.Errorf("pi should be equal to pi")
+	}
+	if !E.SmallerThan(Pi) {
+		t.Errorf("e should be smaller than pi")
+	}
+}
+
+func TestReal_CanBeRepresentedExactly(t *testing.T) {
+	if Pi.CanBeRepresentedExactly(100) {
+		t.Errorf("pi should not be exact")
+	}
+	r, err := Pi.Div(Pi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.CanBeRepresentedExactly(0) {
+		t.Errorf("pi/pi should be exact")
+	}
+}
+
+func TestReal_Mod(t *testing.T) {
+	genericTest := func(r, a *Real, expected string) {
+		res, err := r.Mod(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.String() != expected {
+			t.Errorf("%s mod %s: got %s; want %s", r, a, res, expected)
+		}
+	}
+	genericTest(IntToReal(7), IntToReal(3), "1")
+	genericTest(IntToReal(7), Pi, "7 - 2pi")
+	genericTest(Pi.Neg(), OneReal, "4 - pi")
+	genericTest(Pi.Mul(IntToReal(3)), Pi, "0")
+}
@@ -0,0 +1,106 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+const (
+	// maxRootPrecision is the maximum precision of a root, in bits (about 300 digits)
+	maxRootPrecision = 1024
+)
+
+// rootAtom is a root of a function kept symbolic, like rootof(cos(x) - x, x, 11/16, 3/4).
+// Its approximation is computed by bisecting the interval containing it, which is kept between two calls.
+type rootAtom struct {
+	// id identifies the root, including the values of the variables of the function
+	id, name, latex string
+	f               RealFunction
+
+	mu sync.Mutex
+	// a and b surround the root: f(a) has the sign signA and f(b) the other one
+	a, b  *Fraction
+	signA int
+}
+
+func (r *rootAtom) key() string {
+	return r.id
+}
+
+func (r *rootAtom) String() string {
+	return r.name
+}
+
+func (r *rootAtom) LaTeX() string {
+	return r.latex
+}
+
+func (r *rootAtom) approx(prec uint) *big.Float {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wp := min(prec, maxRootPrecision)
+	// the width of the interval must be smaller than 2^-wp times the root, or than 2^-2wp if the root is close to 0
+	for {
+		width := new(big.Rat).Sub(r.b.Rat, r.a.Rat)
+		width.Abs(width)
+		bound := new(big.Rat).Abs(r.a.Rat)
+		if b := new(big.Rat).Abs(r.b.Rat); b.Cmp(bound) > 0 {
+			bound = b
+		}
+		if width.Cmp(bound.Mul(bound, pow2(-int(wp)))) <= 0 || width.Cmp(pow2(-2*int(wp))) <= 0 {
+			break
+		}
+		m := r.a.Add(r.b).Mul(NewFraction(1, 2))
+		fm, err := r.f(m)
+		// the root is m if f cannot be evaluated or if f(m) cannot be distinguished from 0
+		if err != nil {
+			r.a, r.b = m, m
+			break
+		}
+		switch fm.Sign() {
+		case 0:
+			r.a, r.b = m, m
+		case r.signA:
+			r.a = m
+		default:
+			r.b = m
+		}
+	}
+	return new(big.Float).SetPrec(prec).SetRat(r.a.Add(r.b).Mul(NewFraction(1, 2)).Rat)
+}
+
+// pow2 returns 2^n
+func pow2(n int) *big.Rat {
+	v := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(abs(int64(n)))))
+	if n < 0 {
+		return v.Inv(v)
+	}
+	return v
+}
+
+// NewRoot returns the root of f between a and b as an irrational Real: its digits are computed by bisection when they
+// are requested, like the ones of pi.
+// f must be continuous on [a ; b] and f(a) and f(b) must have opposite signs.
+// id identifies the root (two roots having the same id are equal), name and latex are its representations.
+// Returns ErrIllegalOperation if f(a) and f(b) do not have opposite signs and the errors of f.
+func NewRoot(f RealFunction, a, b *Fraction, id, name, latex string) (*Real, error) {
+	fa, err := f(a)
+	if err != nil {
+		return nil, err
+	}
+	fb, err := f(b)
+	if err != nil {
+		return nil, err
+	}
+	signA := fa.Sign()
+	if signA == 0 || signA == fb.Sign() || fb.Sign() == 0 {
+		return nil, errors.Join(
+			ErrIllegalOperation,
+			fmt.Errorf("%s and %s do not have opposite signs: no root can be isolated between %s and %s", fa, fb, a, b),
+		)
+	}
+	r := &rootAtom{id: id, name: name, latex: latex, f: f, a: a, b: b, signA: signA}
+	return newReal(&term{OneFraction, []*factor{{r, 1}}}), nil
+}
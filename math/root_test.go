@@ -0,0 +1,36 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewRoot(t *testing.T) {
+	genericTest := func(name string, f RealFunction, a, b *Fraction, precision int, excepted string) {
+		r, err := NewRoot(f, a, b, name, name, name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := r.Approx(precision); got != excepted {
+			t.Errorf("%s: got %s; want %s", name, got, excepted)
+		}
+		if r.IsRational() {
+			t.Errorf("%s: a root must not be exact", name)
+		}
+	}
+	square := func(x *Fraction) (*Real, error) { return FractionToReal(x.Mul(x).Sub(IntToFraction(2))), nil }
+	cos := func(x *Fraction) (*Real, error) {
+		c, err := Cos(FractionToReal(x))
+		if err != nil {
+			return nil, err
+		}
+		return c.Sub(FractionToReal(x)), nil
+	}
+	genericTest("x^2 - 2", square, OneFraction, IntToFraction(2), 30, "1.41421356237309504880168872421")
+	genericTest("-(x^2 - 2)", square, IntToFraction(-1), IntToFraction(-2), 20, "-1.4142135623730950488")
+	genericTest("cos(x) - x", cos, NullFraction, OneFraction, 25, "0.7390851332151606416553121")
+
+	if _, err := NewRoot(square, IntToFraction(2), IntToFraction(3), "x^2 - 2", "x^2 - 2", "x^2 - 2"); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("excepted illegal operation, got %v", err)
+	}
+}
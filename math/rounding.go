@@ -0,0 +1,151 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RoundingMode is a rule used to round a Fraction to a fixed number of decimals
+type RoundingMode uint
+
+const (
+	// HalfUp rounds to the nearest value and ties away from zero
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest value and ties to the even one (banker's rounding)
+	HalfEven
+	// HalfDown rounds to the nearest value and ties toward zero
+	HalfDown
+	// TowardZero truncates the extra decimals
+	TowardZero
+	// Floor rounds toward -inf
+	Floor
+	// Ceiling rounds toward +inf
+	Ceiling
+)
+
+// Rounding configures the decimal representations given by Approx.
+// The zero value rounds half away from zero and removes the trailing zeros.
+type Rounding struct {
+	Mode RoundingMode
+	// KeepZeros keeps the trailing zeros, like 1.50 instead of 1.5
+	KeepZeros bool
+}
+
+// ErrUnknownRoundingMode is thrown when a rounding mode cannot be parsed
+var ErrUnknownRoundingMode = errors.New("unknown rounding mode")
+
+var roundingModes = map[string]RoundingMode{
+	"half-up":     HalfUp,
+	"half-even":   HalfEven,
+	"half-down":   HalfDown,
+	"toward-zero": TowardZero,
+	"truncate":    TowardZero,
+	"floor":       Floor,
+	"ceiling":     Ceiling,
+}
+
+// ParseRoundingMode returns the RoundingMode with the given name (half-up, half-even, half-down, toward-zero or
+// truncate, floor or ceiling)
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	if m, ok := roundingModes[s]; ok {
+		return m, nil
+	}
+	return HalfUp, errors.Join(
+		ErrUnknownRoundingMode,
+		fmt.Errorf("unknown rounding mode %s (excepted half-up, half-even, half-down, toward-zero, floor or ceiling)", s),
+	)
+}
+
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfEven:
+		return "half-even"
+	case HalfDown:
+		return "half-down"
+	case TowardZero:
+		return "toward-zero"
+	case Floor:
+		return "floor"
+	case Ceiling:
+		return "ceiling"
+	default:
+		return "half-up"
+	}
+}
+
+// scaled returns the Fraction multiplied by 10^decimals and rounded to an integer with the given mode
+func (f Fraction) scaled(decimals int, mode RoundingMode) *big.Int {
+	num := new(big.Int).Mul(f.Num(), pow10(decimals))
+	// Quo truncates toward zero, so the remainder has the sign of the Fraction
+	q, r := new(big.Int).QuoRem(num, f.Denom(), new(big.Int))
+	sign := big.NewInt(int64(r.Sign()))
+	switch mode {
+	case TowardZero:
+		return q
+	case Floor:
+		if r.Sign() < 0 {
+			q.Add(q, sign)
+		}
+		return q
+	case Ceiling:
+		if r.Sign() > 0 {
+			q.Add(q, sign)
+		}
+		return q
+	}
+	// compares the dropped part with 1/2
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	c := half.Cmp(f.Denom())
+	tie := c == 0 && r.Sign() != 0
+	if c > 0 || (tie && (mode == HalfUp || (mode == HalfEven && q.Bit(0) == 1))) {
+		q.Add(q, sign)
+	}
+	return q
+}
+
+// Round the Fraction to the given number of decimals with the given mode
+func (f Fraction) Round(decimals int, mode RoundingMode) *Fraction {
+	decimals = max(decimals, 0)
+	return &Fraction{new(big.Rat).SetFrac(f.scaled(decimals, mode), pow10(decimals))}
+}
+
+// FixedString returns the decimal representation of the Fraction rounded with the given mode.
+//
+// The trailing zeros are kept: the result always has the given number of decimals (like 12.50).
+func (f Fraction) FixedString(decimals int, mode RoundingMode) string {
+	decimals = max(decimals, 0)
+	n := f.scaled(decimals, mode)
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	s := digits
+	if decimals > 0 {
+		s = digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+	}
+	if n.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// ApproxWith returns the decimal representation of the Fraction with the given number of digits after the decimal
+// point, rounded with r
+func (f Fraction) ApproxWith(precision int, r Rounding) string {
+	s := f.FixedString(precision, r.Mode)
+	if r.KeepZeros || !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
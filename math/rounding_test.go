@@ -0,0 +1,89 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFraction_FixedString(t *testing.T) {
+	genericTest := func(f *Fraction, decimals int, mode RoundingMode, excepted string) {
+		if got := f.FixedString(decimals, mode); got != excepted {
+			t.Errorf("%s with %d decimals (%s): got %s; want %s", f, decimals, mode, got, excepted)
+		}
+	}
+	genericTest(NewFraction(3, 10), 2, HalfEven, "0.30")
+	genericTest(NewFraction(5, 2), 0, HalfEven, "2")
+	genericTest(NewFraction(7, 2), 0, HalfEven, "4")
+	genericTest(NewFraction(5, 2), 0, HalfUp, "3")
+	genericTest(NewFraction(-5, 2), 0, HalfUp, "-3")
+	genericTest(NewFraction(-5, 2), 0, HalfEven, "-2")
+	genericTest(NewFraction(2675, 1000), 2, HalfEven, "2.68")
+	genericTest(NewFraction(2665, 1000), 2, HalfEven, "2.66")
+	genericTest(NewFraction(2, 3), 3, HalfEven, "0.667")
+	genericTest(NewFraction(2, 3), 3, TowardZero, "0.666")
+	genericTest(NewFraction(-2, 3), 3, TowardZero, "-0.666")
+	genericTest(NewFraction(-1, 1000), 2, HalfEven, "0.00")
+	genericTest(NewFraction(1, 200), 2, HalfUp, "0.01")
+	genericTest(IntToFraction(12), 2, HalfEven, "12.00")
+	genericTest(NewFraction(5, 2), 0, HalfDown, "2")
+	genericTest(NewFraction(-5, 2), 0, HalfDown, "-2")
+	genericTest(NewFraction(13, 5), 0, HalfDown, "3")
+	genericTest(NewFraction(2, 3), 2, Floor, "0.66")
+	genericTest(NewFraction(-2, 3), 2, Floor, "-0.67")
+	genericTest(NewFraction(2, 3), 2, Ceiling, "0.67")
+	genericTest(NewFraction(-2, 3), 2, Ceiling, "-0.66")
+	genericTest(NewFraction(-1, 1000), 2, Ceiling, "0.00")
+
+	r := NewFraction(2, 3).Round(2, HalfEven)
+	if !r.Is(NewFraction(67, 100)) {
+		t.Errorf("got %s; want 67/100", r)
+	}
+}
+
+func TestReal_Round(t *testing.T) {
+	genericTest := func(r *Real, decimals int, mode RoundingMode, excepted *Fraction) {
+		if got := r.Round(decimals, mode); !got.Is(excepted) {
+			t.Errorf("%s with %d decimals (%s): got %s; want %s", r, decimals, mode, got, excepted)
+		}
+	}
+	genericTest(Pi, 2, HalfUp, NewFraction(157, 50))
+	genericTest(Pi, 4, HalfUp, NewFraction(31416, 10000))
+	genericTest(Pi, 4, Floor, NewFraction(31415, 10000))
+	genericTest(Pi.Neg(), 2, TowardZero, NewFraction(-157, 50))
+	genericTest(Pi.Neg(), 2, Floor, NewFraction(-315, 100))
+	genericTest(Pi.Mul(IntToReal(1000)), 0, HalfEven, IntToFraction(3142))
+	genericTest(FractionToReal(NewFraction(5, 2)), 0, HalfEven, IntToFraction(2))
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for _, mode := range []RoundingMode{HalfUp, HalfEven, HalfDown, TowardZero, Floor, Ceiling} {
+		got, err := ParseRoundingMode(mode.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != mode {
+			t.Errorf("got %s; want %s", got, mode)
+		}
+	}
+	if got, _ := ParseRoundingMode("truncate"); got != TowardZero {
+		t.Errorf("got %s; want toward-zero", got)
+	}
+	if _, err := ParseRoundingMode("up"); !errors.Is(err, ErrUnknownRoundingMode) {
+		t.Errorf("excepted unknown rounding mode, got %v", err)
+	}
+}
+
+func TestFraction_ApproxWith(t *testing.T) {
+	genericTest := func(f *Fraction, precision int, r Rounding, excepted string) {
+		if got := f.ApproxWith(precision, r); got != excepted {
+			t.Errorf("%s with %d digits (%+v): got %s; want %s", f, precision, r, got, excepted)
+		}
+	}
+	genericTest(NewFraction(1, 2), 3, Rounding{}, "0.5")
+	genericTest(NewFraction(1, 2), 3, Rounding{KeepZeros: true}, "0.500")
+	genericTest(IntToFraction(3), 2, Rounding{KeepZeros: true}, "3.00")
+	genericTest(NewFraction(-1, 1000), 2, Rounding{}, "0")
+	genericTest(NewFraction(-1, 1000), 2, Rounding{Mode: Floor}, "-0.01")
+	genericTest(NewFraction(5, 8), 2, Rounding{Mode: HalfEven}, "0.62")
+	genericTest(NewFraction(5, 8), 2, Rounding{}, "0.63")
+}
@@ -1,14 +1,16 @@
 package math
 
+import "strings"
+
 type Space interface {
-	Contains(f *Fraction) bool
+	Contains(r *Real) bool
 	String() string
 }
 
 type RealSet struct{}
 
 type IntervalBound struct {
-	Value        *Fraction
+	Value        *Real
 	IncludeValue bool
 	Infinite     bool
 	Positive     bool
@@ -26,14 +28,21 @@ type UnionSet struct {
 }
 type PeriodicInterval struct {
 	Interval   *RealInterval
-	Period     *Fraction
+	Period     *Real
+	CustomName string
+}
+
+// FiniteSet is a finite set of numbers, like { -1 ; 1 }.
+// Its values can be complex: Contains only matches the real ones.
+type FiniteSet struct {
+	Values     []*Complex
 	CustomName string
 }
 
 var (
 	SpaceRStar = &RealInterval{
 		LowerBound: &IntervalBound{
-			Value:        NullFraction,
+			Value:        NullReal,
 			IncludeValue: false,
 			Infinite:     false,
 		},
@@ -45,7 +54,7 @@ var (
 	}
 	SpaceRStarPositive = &RealInterval{
 		LowerBound: &IntervalBound{
-			Value:        NullFraction,
+			Value:        NullReal,
 			IncludeValue: false,
 			Infinite:     false,
 		},
@@ -55,16 +64,28 @@ var (
 		},
 		CustomName: `] 0 ; +inf [`,
 	}
+	SpaceRPositive = &RealInterval{
+		LowerBound: &IntervalBound{
+			Value:        NullReal,
+			IncludeValue: true,
+			Infinite:     false,
+		},
+		UpperBound: &IntervalBound{
+			Infinite: true,
+			Positive: true,
+		},
+		CustomName: `[ 0 ; +inf [`,
+	}
 )
 
-func (*RealSet) Contains(*Fraction) bool {
+func (*RealSet) Contains(*Real) bool {
 	return true
 }
 func (*RealSet) String() string {
 	return "R"
 }
 
-func (i *RealInterval) Contains(f *Fraction) bool {
+func (i *RealInterval) Contains(f *Real) bool {
 	return f.smallerThanBound(i.UpperBound) && f.greaterThanBound(i.LowerBound)
 }
 func (i *RealInterval) String() string {
@@ -108,7 +129,7 @@ func (i *RealInterval) String() string {
 	return s
 }
 
-func (s *UnionSet) Contains(f *Fraction) bool {
+func (s *UnionSet) Contains(f *Real) bool {
 	for _, space := range s.Sets {
 		if !space.Contains(f) {
 			return false
@@ -131,7 +152,7 @@ func (s *UnionSet) String() string {
 	return st
 }
 
-func (set *PeriodicInterval) Contains(f *Fraction) bool {
+func (set *PeriodicInterval) Contains(f *Real) bool {
 	if set.Interval.Contains(f) {
 		return true
 	}
@@ -155,7 +176,43 @@ func (set *PeriodicInterval) String() string {
 	return set.Interval.String() + " mod " + set.Period.String()
 }
 
-func (f Fraction) smallerThanBound(b *IntervalBound) bool {
+func (set *FiniteSet) Contains(f *Real) bool {
+	z := RealToComplex(f)
+	for _, v := range set.Values {
+		if v.Is(z) {
+			return true
+		}
+	}
+	return false
+}
+func (set *FiniteSet) String() string {
+	if set.CustomName != "" {
+		return set.CustomName
+	}
+	return set.format(func(z *Complex) string {
+		return z.String()
+	})
+}
+
+// Approx returns the representation of the FiniteSet where each value is approximated with the given precision
+func (set *FiniteSet) Approx(precision int) string {
+	return set.format(func(z *Complex) string {
+		return z.Approx(precision)
+	})
+}
+
+func (set *FiniteSet) format(fn func(*Complex) string) string {
+	if len(set.Values) == 0 {
+		return "∅"
+	}
+	s := make([]string, len(set.Values))
+	for i, v := range set.Values {
+		s[i] = fn(v)
+	}
+	return "{ " + strings.Join(s, " ; ") + " }"
+}
+
+func (f *Real) smallerThanBound(b *IntervalBound) bool {
 	if b.Infinite {
 		return b.Positive
 	}
@@ -165,7 +222,7 @@ func (f Fraction) smallerThanBound(b *IntervalBound) bool {
 	return f.SmallerThan(b.Value)
 }
 
-func (f Fraction) greaterThanBound(b *IntervalBound) bool {
+func (f *Real) greaterThanBound(b *IntervalBound) bool {
 	if b.Infinite {
 		return !b.Positive
 	}
@@ -175,14 +232,14 @@ func (f Fraction) greaterThanBound(b *IntervalBound) bool {
 	return f.GreaterThan(b.Value)
 }
 
-func (f Fraction) strictlySmallerThanBound(b *IntervalBound) bool {
+func (f *Real) strictlySmallerThanBound(b *IntervalBound) bool {
 	if b.Infinite {
 		return b.Infinite
 	}
 	return f.SmallerThan(b.Value)
 }
 
-func (f Fraction) strictlyGreaterThanBound(b *IntervalBound) bool {
+func (f *Real) strictlyGreaterThanBound(b *IntervalBound) bool {
 	if b.Infinite {
 		return !b.Positive
 	}
@@ -8,50 +8,50 @@ func TestRealInterval_Contains(t *testing.T) {
 	t.Log("testing inclusive bounds")
 	set := RealInterval{
 		LowerBound: &IntervalBound{
-			Value:        OneFraction.Neg(),
+			Value:        OneReal.Neg(),
 			IncludeValue: true,
 			Infinite:     false,
 		},
 		UpperBound: &IntervalBound{
-			Value:        OneFraction,
+			Value:        OneReal,
 			IncludeValue: true,
 			Infinite:     false,
 		},
 		CustomName: "",
 	}
 
-	if !set.Contains(NullFraction) {
+	if !set.Contains(NullReal) {
 		t.Errorf("0 should be in [-1, 1]")
 	}
-	if !set.Contains(OneFraction) {
+	if !set.Contains(OneReal) {
 		t.Errorf("1 should be in [-1, 1]")
 	}
 
 	t.Log("testing exclusive bounds")
 	set = RealInterval{
 		LowerBound: &IntervalBound{
-			Value:        OneFraction.Mul(IntToFraction(-1)),
+			Value:        OneReal.Mul(IntToReal(-1)),
 			IncludeValue: false,
 			Infinite:     false,
 		},
 		UpperBound: &IntervalBound{
-			Value:        OneFraction,
+			Value:        OneReal,
 			IncludeValue: false,
 			Infinite:     false,
 		},
 		CustomName: "",
 	}
-	if !set.Contains(NullFraction) {
+	if !set.Contains(NullReal) {
 		t.Errorf("0 should be in ]-1, 1[")
 	}
-	if set.Contains(OneFraction) {
+	if set.Contains(OneReal) {
 		t.Errorf("1 should not be in ]-1, 1[")
 	}
 
 	t.Log("testing infinite bounds")
 	set = RealInterval{
 		LowerBound: &IntervalBound{
-			Value:        OneFraction,
+			Value:        OneReal,
 			Infinite:     false,
 			IncludeValue: true,
 		},
@@ -61,13 +61,13 @@ func TestRealInterval_Contains(t *testing.T) {
 		},
 		CustomName: "",
 	}
-	if set.Contains(NullFraction) {
+	if set.Contains(NullReal) {
 		t.Errorf("0 should not be in [1, +inf[")
 	}
-	if !set.Contains(OneFraction) {
+	if !set.Contains(OneReal) {
 		t.Errorf("1 should be in [1, +inf[")
 	}
-	if !set.Contains(IntToFraction(2)) {
+	if !set.Contains(IntToReal(2)) {
 		t.Errorf("2 should be in [1, +inf[")
 	}
 }
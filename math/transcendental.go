@@ -0,0 +1,367 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// application is the atom f(x), where f is a transcendental function and x a Real.
+// It is approximated with the precision requested by the caller.
+type application struct {
+	name string
+	// format is the format of String (name(%s) if empty)
+	format string
+	// latex is the format of LaTeX
+	latex string
+	arg   *Real
+	fn    func(x *big.Float, prec uint) *big.Float
+}
+
+func (a *application) key() string {
+	return a.String()
+}
+
+func (a *application) String() string {
+	if a.format != "" {
+		return fmt.Sprintf(a.format, a.arg)
+	}
+	return a.name + "(" + a.arg.String() + ")"
+}
+
+func (a *application) LaTeX() string {
+	return fmt.Sprintf(a.latex, a.arg.LaTeX())
+}
+
+func (a *application) approx(prec uint) *big.Float {
+	wp := prec + guardBits
+	x := a.arg.approx(wp)
+	// the absolute error of x matters, so its integer part must be added to the working precision
+	if e := x.MantExp(nil); e > 0 {
+		x = a.arg.approx(wp + uint(e))
+	}
+	return a.fn(x, wp).SetPrec(prec)
+}
+
+// applicationToReal returns the Real f(r)
+func applicationToReal(name, latex string, r *Real, fn func(x *big.Float, prec uint) *big.Float) *Real {
+	return newReal(&term{OneFraction, []*factor{{&application{name: name, latex: latex, arg: r, fn: fn}, 1}}})
+}
+
+// singleFactor returns the atom and its exponent if the Real is only composed of it, like pi^2
+func (r *Real) singleFactor() (atom, int64, bool) {
+	if len(r.terms) != 1 || len(r.terms[0].factors) != 1 || !r.terms[0].coef.Is(OneFraction) {
+		return nil, 0, false
+	}
+	f := r.terms[0].factors[0]
+	return f.atom, f.exp, true
+}
+
+// inverseOf returns the argument of r if r = name(x)
+func (r *Real) inverseOf(name string) (*Real, bool) {
+	a, exp, ok := r.singleFactor()
+	if !ok || exp != 1 {
+		return nil, false
+	}
+	app, ok := a.(*application)
+	if !ok || app.name != name {
+		return nil, false
+	}
+	return app.arg, true
+}
+
+func genErrNotInDomain(fn string, r *Real) error {
+	return errors.Join(ErrIllegalOperation, fmt.Errorf("%s(%s) is not defined", fn, r))
+}
+
+// Sqrt returns the square root of r.
+// Returns ErrIllegalOperation if r is negative.
+func Sqrt(r *Real) (*Real, error) {
+	if r.Sign() < 0 {
+		return nil, genErrNotInDomain("sqrt", r)
+	}
+	return Root(2, r)
+}
+
+// Exp returns e^r.
+// Returns ErrUnsupportedOperation if e^r is too large to be approximated, like exp(10^6).
+func Exp(r *Real) (*Real, error) {
+	if f, ok := r.Fraction(); ok && f.IsInt() {
+		return E.Exp(r)
+	}
+	if x, ok := r.inverseOf("ln"); ok {
+		return x, nil
+	}
+	if expTooLarge(r) {
+		return nil, genErrTooLarge("exp(" + r.String() + ")")
+	}
+	return applicationToReal("exp", `\exp\left(%s\right)`, r, expFloat), nil
+}
+
+// expTooLarge returns true if e^r has more than maxMagnitude bits before the point
+func expTooLarge(r *Real) bool {
+	return r.Float()*math.Log2E > maxMagnitude
+}
+
+// Ln returns the natural logarithm of r.
+// Returns ErrIllegalOperation if r is not strictly positive.
+func Ln(r *Real) (*Real, error) {
+	if r.Sign() <= 0 {
+		return nil, genErrNotInDomain("ln", r)
+	}
+	if r.Is(OneReal) {
+		return NullReal, nil
+	}
+	if a, exp, ok := r.singleFactor(); ok && a.key() == "e" {
+		return IntToReal(exp), nil
+	}
+	if x, ok := r.inverseOf("exp"); ok {
+		return x, nil
+	}
+	return applicationToReal("ln", `\ln\left(%s\right)`, r, lnFloat), nil
+}
+
+// Log2 returns the logarithm in base 2 of r.
+// Returns ErrIllegalOperation if r is not strictly positive.
+func Log2(r *Real) (*Real, error) {
+	return logBase(r, 2, "log2", `\log_2\left(%s\right)`)
+}
+
+// Log10 returns the logarithm in base 10 of r.
+// Returns ErrIllegalOperation if r is not strictly positive.
+func Log10(r *Real) (*Real, error) {
+	return logBase(r, 10, "log10", `\log_{10}\left(%s\right)`)
+}
+
+func logBase(r *Real, base int64, name, latex string) (*Real, error) {
+	if r.Sign() <= 0 {
+		return nil, genErrNotInDomain(name, r)
+	}
+	if f, ok := r.Fraction(); ok {
+		if n, ok := integerLog(f, base); ok {
+			return IntToReal(n), nil
+		}
+	}
+	return applicationToReal(name, latex, r, func(x *big.Float, prec uint) *big.Float {
+		return logFloat(x, base, prec)
+	}), nil
+}
+
+// Log returns the logarithm in base b of r, ln(r)/ln(b).
+// Returns ErrIllegalOperation if r is not strictly positive or if b is not strictly positive or is 1.
+func Log(b, r *Real) (*Real, error) {
+	if b.Sign() <= 0 || b.Is(OneReal) {
+		return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("log(%s, %s) is not defined: invalid base", b, r))
+	}
+	if r.Sign() <= 0 {
+		return nil, errors.Join(ErrIllegalOperation, fmt.Errorf("log(%s, %s) is not defined", b, r))
+	}
+	fb, okB := b.Fraction()
+	fr, okR := r.Fraction()
+	if okB && okR && fb.IsInt() {
+		i, _ := fb.Int()
+		if i.IsInt64() {
+			if n, ok := integerLog(fr, i.Int64()); ok {
+				return IntToReal(n), nil
+			}
+		}
+	}
+	lr, err := Ln(r)
+	if err != nil {
+		return nil, err
+	}
+	lb, err := Ln(b)
+	if err != nil {
+		return nil, err
+	}
+	return lr.Div(lb)
+}
+
+// integerLog returns n if f = base^n
+func integerLog(f *Fraction, base int64) (int64, bool) {
+	num, den := f.Num(), f.Denom()
+	sign := int64(1)
+	if num.Cmp(big.NewInt(1)) == 0 {
+		num, den = den, num
+		sign = -1
+	} else if den.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	n := int64(0)
+	b := big.NewInt(base)
+	m := new(big.Int)
+	q := new(big.Int).Set(num)
+	for q.Cmp(big.NewInt(1)) > 0 {
+		q.DivMod(q, b, m)
+		if m.Sign() != 0 {
+			return 0, false
+		}
+		n++
+	}
+	return sign * n, true
+}
+
+// piMultiple returns q if r = q*pi
+func piMultiple(r *Real) (*Fraction, bool) {
+	q, err := r.Div(Pi)
+	if err != nil {
+		return nil, false
+	}
+	return q.Fraction()
+}
+
+// twelfthTurn returns n in [0 ; 23] if r = n*pi/12 modulo 2pi
+func twelfthTurn(r *Real) (int64, bool) {
+	q, ok := piMultiple(r)
+	if !ok {
+		return 0, false
+	}
+	n := q.Mul(IntToFraction(12))
+	if !n.IsInt() {
+		return 0, false
+	}
+	i, _ := n.Int()
+	return new(big.Int).Mod(i, big.NewInt(24)).Int64(), true
+}
+
+// sinTwelfth returns sin(n*pi/12), with n in [0 ; 23]
+func sinTwelfth(n int64) *Real {
+	sign := int64(1)
+	if n >= 12 {
+		sign = -1
+		n -= 12
+	}
+	if n > 6 {
+		n = 12 - n
+	}
+	sqrt2, _ := Sqrt(IntToReal(2))
+	sqrt3, _ := Sqrt(IntToReal(3))
+	sqrt6, _ := Sqrt(IntToReal(6))
+	var v *Real
+	switch n {
+	case 0:
+		return NullReal
+	case 1:
+		v = sqrt6.Sub(sqrt2)
+	case 2:
+		v = IntToReal(2)
+	case 3:
+		v = sqrt2.Mul(IntToReal(2))
+	case 4:
+		v = sqrt3.Mul(IntToReal(2))
+	case 5:
+		v = sqrt6.Add(sqrt2)
+	case 6:
+		v = IntToReal(4)
+	}
+	v, _ = v.Div(IntToReal(4 * sign))
+	return v
+}
+
+// tanTwelfth returns tan(n*pi/12), with n in [0 ; 23] and n != 6 modulo 12
+func tanTwelfth(n int64) *Real {
+	n %= 12
+	if n > 6 {
+		return tanTwelfth(12 - n).Neg()
+	}
+	sqrt3, _ := Sqrt(IntToReal(3))
+	switch n {
+	case 1:
+		return IntToReal(2).Sub(sqrt3)
+	case 2:
+		v, _ := sqrt3.Div(IntToReal(3))
+		return v
+	case 3:
+		return OneReal
+	case 4:
+		return sqrt3
+	case 5:
+		return IntToReal(2).Add(sqrt3)
+	}
+	return NullReal
+}
+
+// Sin returns the sine of r (in radians).
+// It is exact for the multiples of pi/12.
+func Sin(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		return sinTwelfth(n), nil
+	}
+	if x, ok := r.inverseOf("asin"); ok {
+		return x, nil
+	}
+	return applicationToReal("sin", `\sin\left(%s\right)`, r, sinFloat), nil
+}
+
+// Cos returns the cosine of r (in radians).
+// It is exact for the multiples of pi/12.
+func Cos(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		return sinTwelfth((n + 6) % 24), nil
+	}
+	if x, ok := r.inverseOf("acos"); ok {
+		return x, nil
+	}
+	return applicationToReal("cos", `\cos\left(%s\right)`, r, cosFloat), nil
+}
+
+// Tan returns the tangent of r (in radians).
+// It is exact for the multiples of pi/12.
+// Returns ErrIllegalOperation if r = pi/2 modulo pi.
+func Tan(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		if n%12 == 6 {
+			return nil, genErrNotInDomain("tan", r)
+		}
+		return tanTwelfth(n), nil
+	}
+	if x, ok := r.inverseOf("atan"); ok {
+		return x, nil
+	}
+	return applicationToReal("tan", `\tan\left(%s\right)`, r, tanFloat), nil
+}
+
+// atan returns the arctangent of r.
+// It is exact for the tangents of the multiples of pi/12.
+func atan(r *Real) *Real {
+	sign := r.Sign()
+	if sign == 0 {
+		return NullReal
+	}
+	a := r
+	if sign < 0 {
+		a = r.Neg()
+	}
+	for n := int64(1); n < 6; n++ {
+		if a.Is(tanTwelfth(n)) {
+			res, _ := Pi.Mul(IntToReal(n)).Div(IntToReal(12 * int64(sign)))
+			return res
+		}
+	}
+	return applicationToReal("atan", `\arctan\left(%s\right)`, r, atanFloat)
+}
+
+// Atan2 returns the angle in ]-pi ; pi] of the point (x, y).
+// Returns ErrIllegalOperation if x and y are null.
+func Atan2(y, x *Real) (*Real, error) {
+	sx, sy := x.Sign(), y.Sign()
+	if sx == 0 {
+		if sy == 0 {
+			return nil, errors.Join(ErrIllegalOperation, errors.New("atan2(0, 0) is not defined"))
+		}
+		return Pi.Div(IntToReal(2 * int64(sy)))
+	}
+	q, err := y.Div(x)
+	if err != nil {
+		return nil, err
+	}
+	a := atan(q)
+	if sx > 0 {
+		return a, nil
+	}
+	if sy < 0 {
+		return a.Sub(Pi), nil
+	}
+	return a.Add(Pi), nil
+}
@@ -0,0 +1,202 @@
+package math
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTranscendental_Approx(t *testing.T) {
+	genericTest := func(fn func(*Real) (*Real, error), x *Real, precision int, expected string) {
+		r, err := fn(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Approx(precision); got != expected {
+			t.Errorf("%s: got %s; want %s", r, got, expected)
+		}
+	}
+	genericTest(Sqrt, IntToReal(2), 40, "1.4142135623730950488016887242096980785697")
+	genericTest(Exp, FractionToReal(NewFraction(1, 2)), 30, "1.648721270700128146848650787814")
+	genericTest(Ln, IntToReal(2), 40, "0.6931471805599453094172321214581765680755")
+	genericTest(Ln, FractionToReal(NewFraction(10000001, 10000000)), 25, "0.0000000999999950000003333")
+	genericTest(Log2, IntToReal(3), 20, "1.58496250072115618145")
+	genericTest(Log10, IntToReal(2), 20, "0.30102999566398119521")
+	genericTest(Sin, IntToReal(1), 40, "0.8414709848078965066525023216302989996226")
+	genericTest(Cos, IntToReal(1), 30, "0.540302305868139717400936607443")
+	genericTest(Tan, IntToReal(1), 30, "1.557407724654902230506974807458")
+	genericTest(Sin, IntToReal(100000), 20, "0.03574879797201650932")
+	genericTest(Sec, IntToReal(1), 30, "1.850815717680925617911753241399")
+	genericTest(Csc, IntToReal(1), 30, "1.188395105778121216261599452375")
+	genericTest(Cot, IntToReal(1), 30, "0.642092615934330703006419986594")
+	genericTest(Asin, FractionToReal(NewFraction(1, 3)), 30, "0.339836909454121937096392513392")
+	genericTest(Acos, FractionToReal(NewFraction(1, 3)), 30, "1.230959417340774682134929178248")
+	genericTest(Acos, FractionToReal(NewFraction(999999, 1000000)), 20, "0.00141421368022425176")
+	genericTest(Atan, IntToReal(2), 30, "1.107148717794090503017065460179")
+	genericTest(Sinh, IntToReal(1), 30, "1.175201193643801456882381850596")
+	genericTest(Sinh, FractionToReal(NewFraction(1, 1000000)), 30, "0.000001000000000000166666666667")
+	genericTest(Cosh, IntToReal(1), 30, "1.543080634815243778477905620757")
+	genericTest(Tanh, IntToReal(1), 30, "0.761594155955764888119458282605")
+	genericTest(Asinh, IntToReal(1), 30, "0.88137358701954302523260932498")
+	genericTest(Acosh, IntToReal(2), 30, "1.316957896924816708625046347308")
+	genericTest(Atanh, FractionToReal(NewFraction(1, 2)), 30, "0.549306144334054845697622618461")
+	genericTest(Atanh, FractionToReal(NewFraction(9, 10)), 30, "1.472219489583220230004513715944")
+}
+
+func TestTranscendental_Exact(t *testing.T) {
+	genericTest := func(fn func(*Real) (*Real, error), x *Real, expected string) {
+		r, err := fn(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.String() != expected {
+			t.Errorf("got %s; want %s", r, expected)
+		}
+	}
+	genericTest(Sqrt, FractionToReal(NewFraction(9, 4)), "3/2")
+	genericTest(Sqrt, IntToReal(2), "sqrt(2)")
+	genericTest(Exp, NullReal, "1")
+	genericTest(Exp, IntToReal(2), "e^2")
+	genericTest(Ln, OneReal, "0")
+	genericTest(Ln, E.Mul(E), "2")
+	genericTest(Log10, IntToReal(1000), "3")
+	genericTest(Log2, FractionToReal(NewFraction(1, 8)), "-3")
+	genericTest(Sin, NullReal, "0")
+	genericTest(Cos, NullReal, "1")
+	genericTest(Sin, Pi, "0")
+	genericTest(Cos, Pi, "-1")
+	piOverTwo, err := Pi.Div(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(Sin, piOverTwo.Neg(), "-1")
+	genericTest(Cos, piOverTwo.Mul(IntToReal(5)), "0")
+	twelfth := func(n int64) *Real {
+		r, err := Pi.Mul(IntToReal(n)).Div(IntToReal(12))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	genericTest(Sin, twelfth(2), "1/2")
+	genericTest(Cos, twelfth(3), "sqrt(2)/2")
+	genericTest(Sin, twelfth(-20), "sqrt(3)/2")
+	genericTest(Cos, twelfth(1), "sqrt(2)/4 + sqrt(6)/4")
+	genericTest(Tan, twelfth(5), "2 + sqrt(3)")
+	genericTest(Tan, twelfth(8), "-sqrt(3)")
+	genericTest(Sec, twelfth(4), "2")
+	genericTest(Csc, twelfth(13), "-sqrt(2) - sqrt(6)")
+	genericTest(Cot, twelfth(2), "sqrt(3)")
+	genericTest(Asin, FractionToReal(NewFraction(1, 2)), "pi/6")
+	genericTest(Acos, FractionToReal(NewFraction(-1, 2)), "2pi/3")
+	genericTest(Acos, OneReal, "0")
+	genericTest(Atan, IntToReal(-1), "-pi/4")
+	genericTest(Atan, IntToReal(2).Sub(tanTwelfth(4)), "pi/12")
+	genericTest(Sinh, NullReal, "0")
+	genericTest(Cosh, NullReal, "1")
+	genericTest(Acosh, OneReal, "0")
+	ln2, err := Ln(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(Cosh, ln2, "5/4")
+	genericTest(Tanh, ln2, "3/5")
+
+	ln, err := Ln(IntToReal(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genericTest(Exp, ln, "5")
+
+	log, err := Log(IntToReal(3), FractionToReal(NewFraction(1, 81)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.String() != "-4" {
+		t.Errorf("got %s; want %s", log, "-4")
+	}
+	atan2 := func(y, x int64, expected string) {
+		r, err := Atan2(IntToReal(y), IntToReal(x))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.String() != expected {
+			t.Errorf("atan2(%d, %d): got %s; want %s", y, x, r, expected)
+		}
+	}
+	atan2(1, 1, "pi/4")
+	atan2(1, -1, "3pi/4")
+	atan2(-1, -1, "-3pi/4")
+	atan2(0, -1, "pi")
+	atan2(-2, 0, "-pi/2")
+}
+
+func TestTranscendental_Domain(t *testing.T) {
+	if _, err := Sqrt(IntToReal(-1)); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	if _, err := Ln(NullReal); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	piOverTwo, err := Pi.Div(IntToReal(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Tan(piOverTwo); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	if _, err := Log(OneReal, IntToReal(2)); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	if _, err := Atan2(NullReal, NullReal); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	for _, fn := range []func(*Real) (*Real, error){Csc, Cot} {
+		if _, err := fn(Pi); !errors.Is(err, ErrIllegalOperation) {
+			t.Errorf("expected illegal operation error, not %v", err)
+		}
+	}
+	if _, err := Sec(piOverTwo); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	for _, fn := range []func(*Real) (*Real, error){Asin, Acos} {
+		if _, err := fn(IntToReal(-2)); !errors.Is(err, ErrIllegalOperation) {
+			t.Errorf("expected illegal operation error, not %v", err)
+		}
+	}
+	if _, err := Acosh(NullReal); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+	if _, err := Atanh(OneReal); !errors.Is(err, ErrIllegalOperation) {
+		t.Errorf("expected illegal operation error, not %v", err)
+	}
+}
+
+func TestTranscendental_TooLarge(t *testing.T) {
+	million := IntToReal(1_000_000)
+	for _, fn := range []func(*Real) (*Real, error){Exp, Sinh, Cosh} {
+		if _, err := fn(million); !errors.Is(err, ErrUnsupportedOperation) {
+			t.Errorf("expected unsupported operation error, not %v", err)
+		}
+	}
+	if _, err := Sinh(million.Neg()); !errors.Is(err, ErrUnsupportedOperation) {
+		t.Errorf("expected unsupported operation error, not %v", err)
+	}
+	if _, err := E.Exp(million); !errors.Is(err, ErrUnsupportedOperation) {
+		t.Errorf("expected unsupported operation error, not %v", err)
+	}
+	// the small results are still approximated
+	r, err := Exp(million.Neg())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Approx(3); got != "0" {
+		t.Errorf("got %s; want 0", got)
+	}
+	r, err = Exp(IntToReal(10_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Approx(0); len(got) != 4343 {
+		t.Errorf("got %d digits; want 4343", len(got))
+	}
+}
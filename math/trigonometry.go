@@ -0,0 +1,111 @@
+package math
+
+// cscTwelfth returns 1/sin(n*pi/12), with n in [0 ; 23] and n != 0 modulo 12
+func cscTwelfth(n int64) *Real {
+	switch n % 12 {
+	case 1, 11:
+		// 4/(sqrt(6) - sqrt(2)) cannot be simplified by Inv
+		sqrt2, _ := Sqrt(IntToReal(2))
+		sqrt6, _ := Sqrt(IntToReal(6))
+		v := sqrt6.Add(sqrt2)
+		if n >= 12 {
+			return v.Neg()
+		}
+		return v
+	case 5, 7:
+		sqrt2, _ := Sqrt(IntToReal(2))
+		sqrt6, _ := Sqrt(IntToReal(6))
+		v := sqrt6.Sub(sqrt2)
+		if n >= 12 {
+			return v.Neg()
+		}
+		return v
+	}
+	v, _ := sinTwelfth(n).Inv()
+	return v
+}
+
+// Sec returns the secant of r (in radians).
+// Returns ErrIllegalOperation if r = pi/2 modulo pi.
+func Sec(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		if n%12 == 6 {
+			return nil, genErrNotInDomain("sec", r)
+		}
+		return cscTwelfth((n + 6) % 24), nil
+	}
+	return applicationToReal("sec", `\sec\left(%s\right)`, r, secFloat), nil
+}
+
+// Csc returns the cosecant of r (in radians).
+// Returns ErrIllegalOperation if r = 0 modulo pi.
+func Csc(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		if n%12 == 0 {
+			return nil, genErrNotInDomain("csc", r)
+		}
+		return cscTwelfth(n), nil
+	}
+	return applicationToReal("csc", `\csc\left(%s\right)`, r, cscFloat), nil
+}
+
+// Cot returns the cotangent of r (in radians).
+// Returns ErrIllegalOperation if r = 0 modulo pi.
+func Cot(r *Real) (*Real, error) {
+	if n, ok := twelfthTurn(r); ok {
+		if n%12 == 0 {
+			return nil, genErrNotInDomain("cot", r)
+		}
+		// cot(x) = tan(pi/2 - x)
+		return tanTwelfth((30 - n) % 24), nil
+	}
+	return applicationToReal("cot", `\cot\left(%s\right)`, r, cotFloat), nil
+}
+
+// Asin returns the arcsine of r, in [-pi/2 ; pi/2].
+// It is exact for the sines of the multiples of pi/12.
+// Returns ErrIllegalOperation if r is not in [-1 ; 1].
+func Asin(r *Real) (*Real, error) {
+	if r.GreaterThan(OneReal) || r.SmallerThan(OneReal.Neg()) {
+		return nil, genErrNotInDomain("asin", r)
+	}
+	if n, ok := asinTwelfth(r); ok {
+		return Pi.Mul(IntToReal(n)).Div(IntToReal(12))
+	}
+	return applicationToReal("asin", `\arcsin\left(%s\right)`, r, asinFloat), nil
+}
+
+// Acos returns the arccosine of r, in [0 ; pi].
+// It is exact for the cosines of the multiples of pi/12.
+// Returns ErrIllegalOperation if r is not in [-1 ; 1].
+func Acos(r *Real) (*Real, error) {
+	if r.GreaterThan(OneReal) || r.SmallerThan(OneReal.Neg()) {
+		return nil, genErrNotInDomain("acos", r)
+	}
+	// acos(x) = pi/2 - asin(x)
+	if n, ok := asinTwelfth(r); ok {
+		return Pi.Mul(IntToReal(6 - n)).Div(IntToReal(12))
+	}
+	return applicationToReal("acos", `\arccos\left(%s\right)`, r, acosFloat), nil
+}
+
+// asinTwelfth returns n in [-6 ; 6] if r = sin(n*pi/12)
+func asinTwelfth(r *Real) (int64, bool) {
+	sign := int64(r.Sign())
+	a := r
+	if sign < 0 {
+		a = r.Neg()
+	}
+	for n := int64(0); n <= 6; n++ {
+		if a.Is(sinTwelfth(n)) {
+			return sign * n, true
+		}
+	}
+	return 0, false
+}
+
+// Atan returns the arctangent of r, in ]-pi/2 ; pi/2[.
+// It is exact for the tangents of the multiples of pi/12.
+func Atan(r *Real) (*Real, error) {
+	return atan(r), nil
+}
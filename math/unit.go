@@ -0,0 +1,107 @@
+package math
+
+import (
+	"strings"
+)
+
+// Unit is a unit of measurement, like km
+type Unit struct {
+	Name string
+	// LaTeX is the LaTeX representation of the Unit, like \mathrm{km}
+	LaTeX string
+	// Factor is the value of the Unit measured in the SI base units of its Dimension
+	Factor *Fraction
+	Dim    Dimension
+}
+
+// unitDefinition is a unit known by ParseUnit
+type unitDefinition struct {
+	// symbol is the LaTeX code of the unit written in \mathrm
+	symbol string
+	factor *Fraction
+	dim    Dimension
+	// prefixable is true if the unit can be used with an SI prefix, like km
+	prefixable bool
+}
+
+// prefix is an SI prefix, like k
+type prefix struct {
+	symbol string
+	factor *Fraction
+}
+
+var (
+	units = map[string]*unitDefinition{
+		"m":   {"m", OneFraction, Dimension{1}, true},
+		"g":   {"g", NewFraction(1, 1000), Dimension{0, 1}, true},
+		"s":   {"s", OneFraction, Dimension{0, 0, 1}, true},
+		"A":   {"A", OneFraction, Dimension{0, 0, 0, 1}, true},
+		"K":   {"K", OneFraction, Dimension{0, 0, 0, 0, 1}, true},
+		"mol": {"mol", OneFraction, Dimension{0, 0, 0, 0, 0, 1}, true},
+		"cd":  {"cd", OneFraction, Dimension{0, 0, 0, 0, 0, 0, 1}, true},
+		"Hz":  {"Hz", OneFraction, Dimension{0, 0, -1}, true},
+		"N":   {"N", OneFraction, Dimension{1, 1, -2}, true},
+		"Pa":  {"Pa", OneFraction, Dimension{-1, 1, -2}, true},
+		"J":   {"J", OneFraction, Dimension{2, 1, -2}, true},
+		"W":   {"W", OneFraction, Dimension{2, 1, -3}, true},
+		"C":   {"C", OneFraction, Dimension{0, 0, 1, 1}, true},
+		"V":   {"V", OneFraction, Dimension{2, 1, -3, -1}, true},
+		"ohm": {`\Omega`, OneFraction, Dimension{2, 1, -3, -2}, true},
+		"Ohm": {`\Omega`, OneFraction, Dimension{2, 1, -3, -2}, true},
+		"Ω":   {`\Omega`, OneFraction, Dimension{2, 1, -3, -2}, true},
+		"rad": {"rad", OneFraction, Dimension{}, true},
+		"L":   {"L", NewFraction(1, 1000), Dimension{3}, true},
+		"l":   {"l", NewFraction(1, 1000), Dimension{3}, true},
+		"Wh":  {"Wh", IntToFraction(3600), Dimension{2, 1, -2}, true},
+		"min": {"min", IntToFraction(60), Dimension{0, 0, 1}, false},
+		"h":   {"h", IntToFraction(3600), Dimension{0, 0, 1}, false},
+		"ft":  {"ft", NewFraction(3048, 10000), Dimension{1}, false},
+	}
+
+	prefixes = map[string]*prefix{
+		"T":  {"T", IntToFraction(1_000_000_000_000)},
+		"G":  {"G", IntToFraction(1_000_000_000)},
+		"M":  {"M", IntToFraction(1_000_000)},
+		"k":  {"k", IntToFraction(1000)},
+		"h":  {"h", IntToFraction(100)},
+		"da": {"da", IntToFraction(10)},
+		"d":  {"d", NewFraction(1, 10)},
+		"c":  {"c", NewFraction(1, 100)},
+		"m":  {"m", NewFraction(1, 1000)},
+		"μ":  {`\mu `, NewFraction(1, 1_000_000)},
+		"u":  {`\mu `, NewFraction(1, 1_000_000)},
+		"n":  {"n", NewFraction(1, 1_000_000_000)},
+		"p":  {"p", NewFraction(1, 1_000_000_000_000)},
+	}
+)
+
+// ParseUnit returns the Unit named name, which is an SI unit with an optional prefix, like km or mA.
+// The second value is false if name is not a known unit.
+func ParseUnit(name string) (*Unit, bool) {
+	if u, ok := units[name]; ok {
+		return &Unit{name, `\mathrm{` + u.symbol + `}`, u.factor, u.dim}, true
+	}
+	for p, pre := range prefixes {
+		u, ok := units[strings.TrimPrefix(name, p)]
+		if !ok || !strings.HasPrefix(name, p) || !u.prefixable {
+			continue
+		}
+		return &Unit{name, `\mathrm{` + pre.symbol + u.symbol + `}`, pre.factor.Mul(u.factor), u.dim}, true
+	}
+	return nil, false
+}
+
+// Quantity returns the Quantity 1 Unit, displayed in this Unit
+func (u *Unit) Quantity() *Quantity {
+	return &Quantity{Value: u.Factor, Dim: u.Dim, unit: u}
+}
+
+// per returns the Unit u/a, like km/h
+func (u *Unit) per(a *Unit) *Unit {
+	name := a.Name
+	if strings.ContainsAny(name, " /") {
+		name = "(" + name + ")"
+	}
+	f, _ := u.Factor.Div(a.Factor)
+	return &Unit{u.Name + "/" + name, `\frac{` + u.LaTeX + `}{` + a.LaTeX + `}`, f, u.Dim.mul(a.Dim.scale(-1))}
+}
@@ -53,3 +53,25 @@ func TestMathFunction_Tan(t *testing.T) {
 		t.Errorf("got %v; want %v", res, expected)
 	}
 }
+
+func TestMathFunction_Precision(t *testing.T) {
+	res, err := ParseAndCalculate("sqrt(2)", &ast.Options{Decimal: true, Precision: 40})
+	expected := "1.4142135623730950488016887242096980785697"
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != expected {
+		t.Errorf("got %v; want %v", res, expected)
+	}
+}
+
+func TestMathFunction_Log(t *testing.T) {
+	res, err := ParseAndCalculate("log10(1000)+log2(8)", testOpt)
+	expected := "6"
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != expected {
+		t.Errorf("got %v; want %v", res, expected)
+	}
+}
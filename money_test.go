@@ -0,0 +1,122 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
+
+func TestParse_Money(t *testing.T) {
+	rates := math.NewRateTable("EUR")
+	if err := rates.Set("USD", math.NewFraction(92, 100)); err != nil {
+		t.Fatal(err)
+	}
+	opt := &ast.Options{Money: &ast.MoneyOptions{Decimals: 2, Rates: rates}}
+	genericTest := func(exp, excepted, approx, latex string) {
+		r, err := Parse(exp, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if r.String() != excepted {
+			t.Errorf("%s: got %s; want %s", exp, r, excepted)
+		}
+		if got := r.Approx(3); got != approx {
+			t.Errorf("%s: got %s; want %s", exp, got, approx)
+		}
+		if got := r.ExactLaTeX(); got != latex {
+			t.Errorf("%s: got %s; want %s", exp, got, latex)
+		}
+	}
+	genericTest("0.1 + 0.2", "0.30", "0.300", "0.30")
+	genericTest("12.5 EUR + 3 EUR", "15.50 EUR", "15.500 EUR", `15.50\,\mathrm{EUR}`)
+	genericTest("10 EUR / 3", "3.33 EUR", "3.333 EUR", `3.33\,\mathrm{EUR}`)
+	genericTest("12.5 EUR + 3 USD", "15.26 EUR", "15.260 EUR", `15.26\,\mathrm{EUR}`)
+	genericTest("convert(100 EUR, USD)", "108.70 USD", "108.696 USD", `108.70\,\mathrm{USD}`)
+	genericTest("-1.005 EUR", "-1.01 EUR", "-1.005 EUR", `-1.01\,\mathrm{EUR}`)
+
+	r, err := Parse("100 EUR * 1.05^2", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, ok := r.Money()
+	if !ok || !m.Amount.Is(math.NewFraction(441, 4)) || m.Currency != "EUR" {
+		t.Errorf("got %v; want 441/4 EUR", m)
+	}
+
+	// the irrational numbers are rounded when they are multiplied by an amount
+	genericTest("100 EUR * 1.05^(1/12)", "100.41 EUR", "100.410 EUR", `100.41\,\mathrm{EUR}`)
+	genericTest("sqrt(2) EUR", "1.41 EUR", "1.410 EUR", `1.41\,\mathrm{EUR}`)
+	genericTest("10 EUR / pi", "3.18 EUR", "3.180 EUR", `3.18\,\mathrm{EUR}`)
+	genericTest("sqrt(2)", "1.41", "1.410", "1.41")
+	// the piecewise expressions and the user-defined functions can return amounts
+	genericTest("if(2 > 1, 5 EUR, 3 EUR) + 1 EUR", "6.00 EUR", "6.000 EUR", `6.00\,\mathrm{EUR}`)
+	genericTest("piecewise(12 EUR < 10 EUR, 5 EUR, 12 EUR < 20 EUR, 4 EUR, 3 EUR)", "4.00 EUR", "4.000 EUR", `4.00\,\mathrm{EUR}`)
+
+	reg := expression.DefaultRegistry.Clone()
+	for name, def := range map[string]string{
+		"price": "n -> n * 2.5 EUR",
+		"tax":   "a -> if(a > 100 EUR, a * 0.2, 0 EUR)",
+	} {
+		f, err := NewFunction(def, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", def, err)
+		}
+		if err = f.Register(name, reg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	withFuncs := &ast.Options{Registry: reg, Money: opt.Money}
+	for exp, excepted := range map[string]string{
+		"price(3)":            "7.50 EUR",
+		"tax(price(50))":      "25.00 EUR",
+		"tax(50 USD)":         "0.00 EUR",
+		"price(2) + 1 EUR":    "6.00 EUR",
+		"price(3) / price(1)": "3.00",
+	} {
+		r, err := Parse(exp, withFuncs)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if r.String() != excepted {
+			t.Errorf("%s: got %s; want %s", exp, r, excepted)
+		}
+	}
+
+	for exp, excepted := range map[string]bool{"10 EUR > 5 USD": true, "9.2 EUR == 10 USD": true, "1 EUR >= 1.1 EUR": false} {
+		r, err := Parse(exp, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if b, ok := r.Boolean(); !ok || b != excepted {
+			t.Errorf("%s: got %s; want %t", exp, r, excepted)
+		}
+	}
+
+	even := &ast.Options{Money: &ast.MoneyOptions{Decimals: 2, Rounding: math.HalfEven}}
+	r, err = Parse("2.345 EUR", even)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "2.34 EUR" {
+		t.Errorf("got %s; want 2.34 EUR", r)
+	}
+
+	for _, exp := range []string{"1 EUR * 1 EUR", "1 EUR + 1", "sqrt(4 EUR)", "2^(1 EUR)", "10 EUR > 5"} {
+		if _, err := Parse(exp, opt); !errors.Is(err, math.ErrCurrencyMismatch) {
+			t.Errorf("%s: excepted currency mismatch, got %v", exp, err)
+		}
+	}
+	if _, err := Parse("1 GBP + 1 EUR", opt); !errors.Is(err, math.ErrUnknownCurrency) {
+		t.Errorf("excepted unknown currency, got %v", err)
+	}
+	// the codes which are not in ISO 4217 are variables
+	if _, err := Parse("10 XYZ", opt); !errors.Is(err, expression.ErrUnknownVariable) {
+		t.Errorf("excepted unknown variable, got %v", err)
+	}
+	// without the option, the currencies are variables
+	if _, err := Parse("1 EUR"); err == nil {
+		t.Errorf("excepted an error")
+	}
+}
@@ -0,0 +1,53 @@
+package gomath
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+)
+
+var (
+	// ErrNotPolynomial is thrown when an expression is not a polynomial with rational coefficients
+	ErrNotPolynomial = errors.New("not a polynomial")
+)
+
+// ParsePolynomial returns the expression as a polynomial in x with rational coefficients, like x^2 - 3x/2 + 1.
+// The optional Options give the values of the other variables and the expression.Registry used to parse the
+// expression.
+// Returns ErrNotPolynomial if the expression is not a polynomial in x, like 1/x, or if a coefficient is not
+// rational, like in sqrt(2)x.
+func ParsePolynomial(exp string, x string, opts ...*ast.Options) (*math.Polynomial, error) {
+	if err := checkParameter(x, nil); err != nil {
+		return nil, errors.Join(ErrInvalidVariable, err)
+	}
+	var opt *ast.Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	tree, err := parseAst(exp, ast.TypeCalculation, opt)
+	if err != nil {
+		return nil, err
+	}
+	var scope *expression.Scope
+	if opt != nil {
+		scope = opt.EvalScope()
+	}
+	coefs, ok, err := expression.Coefficients(tree.Expression(), x, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Join(ErrNotPolynomial, fmt.Errorf("%s is not a polynomial in %s", exp, x))
+	}
+	fracs := make([]*math.Fraction, len(coefs))
+	for i, c := range coefs {
+		f, ok := c.Fraction()
+		if !ok {
+			return nil, errors.Join(ErrNotPolynomial, fmt.Errorf("the coefficient %s is not rational", c))
+		}
+		fracs[i] = f
+	}
+	return math.NewPolynomial(x, fracs...), nil
+}
@@ -0,0 +1,44 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
+
+func TestParsePolynomial(t *testing.T) {
+	genericTest := func(exp, excepted, exceptedLatex string) {
+		p, err := ParsePolynomial(exp, "x")
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if got := p.String(); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+		if got := p.LaTeX(); got != exceptedLatex {
+			t.Errorf("%s: got %s; want %s", exp, got, exceptedLatex)
+		}
+	}
+	genericTest("(x + 1)^2 - 1", "x^2 + 2x", "x^{2} + 2x")
+	genericTest("x^3/2 - 3x/4 + 0.5", "x^3/2 - 3x/4 + 1/2", `\frac{1}{2}x^{3} - \frac{3}{4}x + \frac{1}{2}`)
+	genericTest("x - x", "0", "0")
+
+	scope := expression.NewScope(nil)
+	scope.SetFraction("a", math.NewFraction(1, 3))
+	p, err := ParsePolynomial("a*x^2 + a", "x", &ast.Options{Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.String(); got != "x^2/3 + 1/3" {
+		t.Errorf("got %s; want x^2/3 + 1/3", got)
+	}
+
+	if _, err = ParsePolynomial("1/x", "x"); !errors.Is(err, ErrNotPolynomial) {
+		t.Errorf("excepted not polynomial error, got %v", err)
+	}
+	if _, err = ParsePolynomial("sqrt(2)*x", "x"); !errors.Is(err, ErrNotPolynomial) {
+		t.Errorf("excepted not polynomial error, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
+
+func TestParse_Units(t *testing.T) {
+	opt := &ast.Options{Units: true}
+	genericTest := func(exp, excepted, approx, latex string) {
+		r, err := Parse(exp, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if r.String() != excepted {
+			t.Errorf("%s: got %s; want %s", exp, r, excepted)
+		}
+		if got := r.Approx(2); got != approx {
+			t.Errorf("%s: got %s; want %s", exp, got, approx)
+		}
+		if got := r.ExactLaTeX(); got != latex {
+			t.Errorf("%s: got %s; want %s", exp, got, latex)
+		}
+	}
+	genericTest("3 m + 20 cm", "16/5 m", "3.2 m", `\frac{16}{5}\,\mathrm{m}`)
+	genericTest("9.81 m/s^2 * 2 kg", "981/50 m kg/s^2", "19.62 m kg/s^2", `\frac{981}{50}\,\mathrm{m}\,\mathrm{kg}\,\mathrm{s}^{-2}`)
+	genericTest("convert(100 km/h, m/s)", "250/9 m/s", "27.78 m/s", `\frac{250}{9}\,\frac{\mathrm{m}}{\mathrm{s}}`)
+	genericTest("convert(2 kW*h, MJ)", "36/5 MJ", "7.2 MJ", `\frac{36}{5}\,\mathrm{MJ}`)
+	genericTest("(3 m)^2", "9 m^2", "9 m^2", `9\,\mathrm{m}^{2}`)
+	genericTest("2 km/(500 m)", "4", "4", "4")
+	genericTest("3 h", "3 h", "3 h", `3\,\mathrm{h}`)
+	genericTest("5 min + 1 h", "65 min", "65 min", `65\,\mathrm{min}`)
+	genericTest("100 km/h", "100 km/h", "100 km/h", `100\,\frac{\mathrm{km}}{\mathrm{h}}`)
+	genericTest("convert(90 min, h)", "3/2 h", "1.5 h", `\frac{3}{2}\,\mathrm{h}`)
+	genericTest("min(2, 3) m", "2 m", "2 m", `2\,\mathrm{m}`)
+	// rational exponents are supported when the exponents of the units are divisible
+	genericTest("sqrt(4 m^2)", "2 m", "2 m", `2\,\mathrm{m}`)
+	genericTest("(9 m^2/s^2)^(1/2)", "3 m/s", "3 m/s", `3\,\mathrm{m}\,\mathrm{s}^{-1}`)
+	genericTest("root(3, 8 m^3)", "2 m", "2 m", `2\,\mathrm{m}`)
+	genericTest("convert(2 l, mL)", "2000 mL", "2000 mL", `2000\,\mathrm{mL}`)
+	genericTest("convert(1 kWh, MJ)", "18/5 MJ", "3.6 MJ", `\frac{18}{5}\,\mathrm{MJ}`)
+	genericTest("convert(10 ft, cm)", "1524/5 cm", "304.8 cm", `\frac{1524}{5}\,\mathrm{cm}`)
+	genericTest("convert(2 kOhm, ohm)", "2000 ohm", "2000 ohm", `2000\,\mathrm{\Omega}`)
+	// the radian is the number 1
+	genericTest("2 rad", "2", "2", "2")
+
+	for exp, excepted := range map[string]bool{"1 km < 2 m": false, "1 km >= 2 m": true, "5 min == 300 s": true} {
+		r, err := Parse(exp, opt)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if b, ok := r.Boolean(); !ok || b != excepted {
+			t.Errorf("%s: got %s; want %t", exp, r, excepted)
+		}
+	}
+
+	r, err := Parse("convert(1 L, cm^3)", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, ok := r.Quantity()
+	if !ok || !q.Value.Is(math.NewFraction(1, 1000)) || q.Dim != (math.Dimension{3}) {
+		t.Errorf("got %v; want 1/1000 m^3", q)
+	}
+	if q.String() != "1000 cm^3" {
+		t.Errorf("got %s; want 1000 cm^3", q)
+	}
+
+	latex, err := ParseAndConvertToLaTeX("3 m + 20 cm", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latex != `3\,\mathrm{m} + 20\,\mathrm{cm}` {
+		t.Errorf("got %s; want %s", latex, `3\,\mathrm{m} + 20\,\mathrm{cm}`)
+	}
+
+	for _, exp := range []string{"1 m + 1 s", "convert(1 m, s)", "sqrt(4 m)", "(2 m)^(1/2)", "sin(1 m)", "2^(1 m)", "convert(3, m)", "1 m < 1 s"} {
+		if _, err := Parse(exp, opt); !errors.Is(err, math.ErrDimensionMismatch) {
+			t.Errorf("%s: excepted dimension mismatch, got %v", exp, err)
+		}
+	}
+	// without the option, the units are variables
+	if _, err := Parse("3 m"); !errors.Is(err, expression.ErrUnknownVariable) {
+		t.Errorf("excepted unknown variable, got %v", err)
+	}
+	// the constants are not shadowed by the units
+	r, err = Parse("2pi", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Quantity(); ok || r.String() != "2pi" {
+		t.Errorf("got %s; want 2pi", r)
+	}
+}
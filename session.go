@@ -0,0 +1,268 @@
+package gomath
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/lexer"
+	"github.com/nyttikord/gomath/math"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrInvalidAssignment is thrown when a variable or a function cannot be defined in a Session
+	ErrInvalidAssignment = errors.New("invalid assignment")
+	// ErrUnknownCommand is thrown when a Session does not know the given command, like :foo
+	ErrUnknownCommand = errors.New("unknown command")
+)
+
+// Session keeps the variables and the functions defined by each line executed, like a calculator.
+//
+//	a = 3/4
+//	f(x) = x^2 + a
+//	f(2)
+//
+// The last Result is stored in the variable ans.
+type Session struct {
+	// Precision is the precision of the decimal approximation written after irrational results
+	Precision int
+	// Angle is the unit of the angles used by the trigonometric functions
+	Angle expression.AngleUnit
+	// Rounding is the rule used to round the decimal approximation
+	Rounding math.Rounding
+
+	scope    *expression.Scope
+	registry *expression.Registry
+	tpe      ast.Type
+	ans      Result
+	// vars and funcs are the names and the definitions of the variables and of the functions, in order of definition
+	vars  []string
+	funcs []string
+	defs  map[string]string
+}
+
+// NewSession creates an empty Session
+func NewSession() *Session {
+	s := &Session{Precision: 6}
+	s.Clear()
+	return s
+}
+
+// Clear removes every variable and every function of the Session
+func (s *Session) Clear() {
+	s.scope = expression.NewScope(nil)
+	s.registry = expression.DefaultRegistry.Clone()
+	s.ans = nil
+	s.vars = nil
+	s.funcs = nil
+	s.defs = map[string]string{}
+}
+
+// Ans returns the last Result computed (nil if there is no Result)
+func (s *Session) Ans() Result {
+	return s.ans
+}
+
+// Type returns the ast.Type used to evaluate the expressions (ast.TypeLatex converts them to LaTeX)
+func (s *Session) Type() ast.Type {
+	return s.tpe
+}
+
+// Options returns the Options used to parse the expressions in the Session
+func (s *Session) Options() *ast.Options {
+	return &ast.Options{Scope: s.scope, Registry: s.registry, Angle: s.Angle, Rounding: s.Rounding}
+}
+
+// Exec executes a line and returns the text to display.
+// A line is either:
+//   - an expression, like f(2) + ans;
+//   - the assignment of a variable, like a = 3/4;
+//   - the definition of a function, like f(x) = x^2 + a;
+//   - a command: :vars, :funcs, :clear or :latex.
+func (s *Session) Exec(line string) (string, error) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, ":") {
+		return s.command(line)
+	}
+	if name, params, body, ok := splitDefinition(line); ok {
+		if params == nil {
+			return s.setVariable(name, body)
+		}
+		return s.setFunction(name, params, body)
+	}
+	if s.tpe == ast.TypeLatex {
+		return ParseAndConvertToLaTeX(line, s.Options())
+	}
+	r, err := s.Eval(line)
+	if err != nil {
+		return "", err
+	}
+	return s.format(r), nil
+}
+
+// Eval evaluates the expression with the variables and the functions of the Session.
+// The Result is stored in ans.
+func (s *Session) Eval(exp string) (Result, error) {
+	r, err := Parse(exp, s.Options())
+	if err != nil {
+		return nil, err
+	}
+	s.ans = r
+	if z := r.(*res).result.Complex(); z != nil {
+		s.scope.SetComplex("ans", z)
+	}
+	return r, nil
+}
+
+func (s *Session) format(r Result) string {
+	if _, ok := r.Boolean(); ok || r.IsExact(s.Precision) {
+		return r.String()
+	}
+	return fmt.Sprintf("%s ≈ %s", r, r.Approx(s.Precision))
+}
+
+func (s *Session) command(line string) (string, error) {
+	switch line {
+	case ":vars":
+		lines := make([]string, len(s.vars))
+		for i, v := range s.vars {
+			lines[i] = s.defs[v]
+		}
+		return strings.Join(lines, "\n"), nil
+	case ":funcs":
+		lines := make([]string, len(s.funcs))
+		for i, f := range s.funcs {
+			lines[i] = s.defs[f]
+		}
+		return strings.Join(lines, "\n"), nil
+	case ":clear":
+		s.Clear()
+		return "cleared", nil
+	case ":latex":
+		if s.tpe == ast.TypeLatex {
+			s.tpe = ast.TypeCalculation
+			return "LaTeX mode off", nil
+		}
+		s.tpe = ast.TypeLatex
+		return "LaTeX mode on", nil
+	}
+	return "", errors.Join(
+		ErrUnknownCommand,
+		fmt.Errorf("%s (commands are :vars, :funcs, :clear and :latex)", line),
+	)
+}
+
+func (s *Session) setVariable(name, body string) (string, error) {
+	if err := s.checkName(name); err != nil {
+		return "", err
+	}
+	if slices.Contains(s.funcs, name) {
+		return "", errors.Join(ErrInvalidAssignment, fmt.Errorf("%s is already a function", name))
+	}
+	r, err := Parse(body, s.Options())
+	if err != nil {
+		return "", err
+	}
+	z := r.(*res).result.Complex()
+	if z == nil {
+		return "", errors.Join(ErrInvalidAssignment, fmt.Errorf("%s is not a number", body))
+	}
+	s.scope.SetComplex(name, z)
+	def := fmt.Sprintf("%s = %s", name, r)
+	if _, ok := s.defs[name]; !ok {
+		s.vars = append(s.vars, name)
+	}
+	s.defs[name] = def
+	return def, nil
+}
+
+func (s *Session) setFunction(name string, params []string, body string) (string, error) {
+	if err := s.checkName(name); err != nil {
+		return "", err
+	}
+	if slices.Contains(s.vars, name) {
+		return "", errors.Join(ErrInvalidAssignment, fmt.Errorf("%s is already a variable", name))
+	}
+	f, err := NewFunction(strings.Join(params, ", ")+" -> "+body, s.Options())
+	if err != nil {
+		return "", err
+	}
+	if err = f.Register(name, s.registry); err != nil {
+		return "", err
+	}
+	def := fmt.Sprintf("%s(%s) = %s", name, strings.Join(params, ", "), body)
+	if _, ok := s.defs[name]; !ok {
+		s.funcs = append(s.funcs, name)
+	}
+	s.defs[name] = def
+	return def, nil
+}
+
+// checkName returns an error if name is defined by GoMath
+func (s *Session) checkName(name string) error {
+	if name == "ans" || ast.IsKeyword(name) || expression.IsPredefinedVariable(name) ||
+		expression.IsPredefinedFunction(name) {
+		return errors.Join(ErrInvalidAssignment, fmt.Errorf("%s is already defined by GoMath", name))
+	}
+	return nil
+}
+
+// splitDefinition splits a line like a = 3/4 or f(x, y) = x^y.
+// params is nil if the line defines a variable.
+// ok is false if the line is not a definition.
+func splitDefinition(line string) (name string, params []string, body string, ok bool) {
+	i := definitionIndex(line)
+	if i < 0 {
+		return "", nil, "", false
+	}
+	tkl, err := lexer.Lex(strings.TrimSpace(line[:i]))
+	if err != nil || !tkl.Next() || tkl.Current().Type != lexer.Literal {
+		return "", nil, "", false
+	}
+	name = tkl.Current().Value
+	body = strings.TrimSpace(line[i+1:])
+	if !tkl.Next() {
+		return name, nil, body, true
+	}
+	if tkl.Current().Value != "(" {
+		return "", nil, "", false
+	}
+	params = []string{}
+	for tkl.Next() && tkl.Current().Type == lexer.Literal {
+		params = append(params, tkl.Current().Value)
+		if !tkl.Next() {
+			return "", nil, "", false
+		}
+		switch tkl.Current().Value {
+		case ",":
+			continue
+		case ")":
+			if tkl.Next() {
+				return "", nil, "", false
+			}
+			return name, params, body, true
+		}
+		return "", nil, "", false
+	}
+	return "", nil, "", false
+}
+
+// definitionIndex returns the index of the = of a definition, or -1 if there is no such =
+func definitionIndex(line string) int {
+	for i, c := range line {
+		if c != '=' {
+			continue
+		}
+		// ignores ==, <=, >= and !=
+		if i > 0 && strings.ContainsRune("=<>!", rune(line[i-1])) {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '=' {
+			continue
+		}
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/expression"
+	"testing"
+)
+
+func TestSession_Exec(t *testing.T) {
+	s := NewSession()
+	genericTest := func(line, excepted string) {
+		got, err := s.Exec(line)
+		if err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+		if got != excepted {
+			t.Errorf("%s: got %s; want %s", line, got, excepted)
+		}
+	}
+	genericTest("a = 3/4", "a = 3/4")
+	genericTest("f(x) = x^2 + a", "f(x) = x^2 + a")
+	genericTest("f(2)", "19/4")
+	genericTest("ans*4", "19")
+	genericTest("a = a + 1/4", "a = 1")
+	genericTest("f(2)", "5")
+	genericTest("f(2) == 5", "true")
+	genericTest("sqrt(2)", "sqrt(2) ≈ 1.414214")
+	genericTest("g(x, y) = f(x) + y", "g(x, y) = f(x) + y")
+	genericTest(":vars", "a = 1")
+	genericTest(":funcs", "f(x) = x^2 + a\ng(x, y) = f(x) + y")
+	genericTest(":latex", "LaTeX mode on")
+	genericTest("g(1, 2)/3", `\frac{g\left(1, 2\right)}{3}`)
+	genericTest(":latex", "LaTeX mode off")
+	if s.Ans().String() != "sqrt(2)" {
+		t.Errorf("got %s; want sqrt(2)", s.Ans())
+	}
+	genericTest(":clear", "cleared")
+	genericTest(":vars", "")
+	if _, err := s.Exec("a"); !errors.Is(err, expression.ErrUnknownVariable) {
+		t.Errorf("excepted unknown variable error, got %v", err)
+	}
+	s.Angle = expression.Degree
+	genericTest("h(x) = sin(x)^2 + cos(x)^2", "h(x) = sin(x)^2 + cos(x)^2")
+	genericTest("h(30)", "1")
+	genericTest("acos(0)", "90")
+}
+
+func TestSession_LexicalScope(t *testing.T) {
+	s := NewSession()
+	for _, line := range []string{"a = 1", "f(x) = x + a", "g(a) = f(0)"} {
+		if _, err := s.Exec(line); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+	// a is the variable of the Session in f, not the parameter of g
+	got, err := s.Exec("g(5)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1" {
+		t.Errorf("got %s; want 1", got)
+	}
+}
+
+func TestSession_Errors(t *testing.T) {
+	s := NewSession()
+	for _, line := range []string{"pi = 3", "sqrt(x) = x", "ans = 2", "and = 1"} {
+		if _, err := s.Exec(line); !errors.Is(err, ErrInvalidAssignment) {
+			t.Errorf("%s: excepted invalid assignment error, got %v", line, err)
+		}
+	}
+	if _, err := s.Exec(":foo"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("excepted unknown command error, got %v", err)
+	}
+}
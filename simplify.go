@@ -0,0 +1,21 @@
+package gomath
+
+import (
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+)
+
+// Simplify returns the expression written in a simpler form, like 4x for 2x + 3x - x or x^6 for (x^2)^3.
+// The optional Options give the expression.Registry used to parse the expression.
+// Use expression.String to get its plain text and RenderLatex to get its LaTeX code.
+func Simplify(exp string, opts ...*ast.Options) (expression.Expression, error) {
+	var opt *ast.Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	tree, err := parseAst(exp, ast.TypeCalculation, opt)
+	if err != nil {
+		return nil, err
+	}
+	return expression.Simplify(tree.Expression()), nil
+}
@@ -0,0 +1,116 @@
+package gomath
+
+import (
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	genericTest := func(exp, excepted, exceptedLatex string) {
+		s, err := Simplify(exp)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		if got := expression.String(s); got != excepted {
+			t.Errorf("%s: got %s; want %s", exp, got, excepted)
+		}
+		latex, _, err := s.RenderLatex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latex != exceptedLatex {
+			t.Errorf("%s: got %s; want %s", exp, latex, exceptedLatex)
+		}
+	}
+	genericTest("2x + 3x - x", "4x", `4 \times x`)
+	genericTest("(x^2)^3", "x^6", "x^6")
+	genericTest("2*3 + 1", "7", "7")
+	genericTest("x*x^2", "x^3", "x^3")
+	genericTest("-(-x)", "x", "x")
+	genericTest("6x^2/(4x)", "3x/2", `\frac{3 \times x}{2}`)
+	genericTest("x^2*y/(x*y^2)", "x/y", `\frac{x}{y}`)
+	genericTest("x/2 + x/3", "5x/6", `\frac{5 \times x}{6}`)
+	genericTest("(x + 1)*(x + 1) - 2(x + 1)", "(x + 1)^2 - 2(x + 1)",
+		`\left(x + 1\right)^2 - 2 \times \left(x + 1\right)`)
+	genericTest("1 + x - (2 - y) - y", "x - 1", "x - 1")
+	genericTest("sin(x)*x + x*sin(x)", "2x*sin(x)", `2 \times x \times \sin\left(x\right)`)
+	genericTest("sqrt(x)*sqrt(x)", "x", "x")
+	genericTest("x^(-1/2)", "1/sqrt(x)", `\frac{1}{\sqrt{x}}`)
+	genericTest("2pi + pi", "3pi", `3\pi`)
+	genericTest("(1 + sqrt(2))*x + x", "(2 + sqrt(2))*x", `\left(2 + \sqrt{2}\right) \times x`)
+	genericTest("if(1 > 2, x, x + x)", "2x", `2 \times x`)
+}
+
+func TestSimplify_Eval(t *testing.T) {
+	// the simplified expressions are parsed again and evaluated at x = 3 and y = 2
+	genericTest := func(exp string) {
+		s, err := Simplify(exp)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		opt := func() *ast.Options {
+			scope := expression.NewScope(nil)
+			scope.SetFraction("x", math.IntToFraction(3))
+			scope.SetFraction("y", math.IntToFraction(2))
+			return &ast.Options{Scope: scope}
+		}
+		got, err := Parse(expression.String(s), opt())
+		if err != nil {
+			t.Fatalf("%s: %v", expression.String(s), err)
+		}
+		want, err := Parse(exp, opt())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("%s: got %s; want %s", exp, got, want)
+		}
+	}
+	genericTest("3y/(x*y) - x^2/x")
+	genericTest("(2x^2)^3 - 7x^6 + y^-2")
+	genericTest("-x*-y + 2*(x + y)/4")
+	genericTest("sqrt(2)*x/sqrt(2) + mod(x, y)")
+	genericTest("ln(x)*2 - ln(x) + x!")
+}
+
+func TestString_RoundTrip(t *testing.T) {
+	// the plain representation must be parsed again as the same expression
+	genericTest := func(exp string) {
+		tree, err := parseAst(exp, ast.TypeCalculation, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", exp, err)
+		}
+		s := expression.String(tree.Expression())
+		again, err := parseAst(s, ast.TypeCalculation, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if got := expression.String(again.Expression()); got != s {
+			t.Errorf("%s: got %s; want %s", exp, got, s)
+		}
+	}
+	genericTest("(1/3)*x^(-2/3)")
+	genericTest("(a/2)*x")
+	genericTest("2x*sin(x)")
+	genericTest("(2^3)*x")
+	genericTest("-(x + 1)/(2y)")
+
+	d, err := Derive("x^(1/3)", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := expression.String(d)
+	got, err := Parse(s, &ast.Options{Scope: func() *expression.Scope {
+		scope := expression.NewScope(nil)
+		scope.SetFraction("x", math.IntToFraction(8))
+		return scope
+	}()})
+	if err != nil {
+		t.Fatalf("%s: %v", s, err)
+	}
+	if got.String() != "1/12" {
+		t.Errorf("%s at x = 8: got %s; want 1/12", s, got)
+	}
+}
@@ -0,0 +1,416 @@
+package gomath
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"math/big"
+	"slices"
+)
+
+var (
+	// ErrInvalidEquation is thrown when the equation cannot be solved
+	ErrInvalidEquation = errors.New("invalid equation")
+	// ErrNoRootFound is thrown when the numeric search does not find any root
+	ErrNoRootFound = errors.New("no root found")
+)
+
+const (
+	// maxBisection is the number of steps used to check that a sign change is a root
+	maxBisection = 40
+	// maxSnapDenominator is the biggest denominator of the rational numbers tried as exact roots
+	maxSnapDenominator = 1000
+	// periodPrecision is the number of digits compared to check if the equation is periodic
+	periodPrecision = 30
+	// searchBound is the bound of the interval [-searchBound ; searchBound] where the numeric roots are searched
+	searchBound = 100
+)
+
+// Solve the given equation in one unknown, like x^2 = 2, and returns its solution set.
+//
+// Linear and quadratic equations are solved exactly in the complex plane, so x^2 = -1 gives { -i ; i }.
+// Polynomial equations with rational coefficients are factored: the factors of degree 2 or less are solved exactly and
+// the real roots of the other ones are isolated with Sturm's theorem, so none is missed (their non-real roots are not
+// computed).
+// Other equations are solved numerically: their real roots are searched between -100 and 100.
+// A numeric root is exact if it is a rational number with a small denominator, otherwise it is an irrational Real
+// whose digits are computed by bisection with the precision requested, like rootof(cos(x) - x, x, 11/16, 3/4).
+// Returns ErrInvalidEquation if the equation has infinitely many solutions, like x/x = 1 or sin(x) = 1/2, and
+// ErrNoRootFound if the numeric search does not find any root.
+// The optional Options give the values of the other variables, the angle unit and the expression.Registry used to
+// parse the equation.
+func Solve(equation string, opts ...*ast.Options) (math.Space, error) {
+	var opt *ast.Options
+	var scope *expression.Scope
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+		scope = opt.EvalScope()
+	}
+	tree, err := parseAst(equation, ast.TypeCalculation, opt)
+	if err != nil {
+		return nil, err
+	}
+	eq, ok := tree.Expression().(*expression.Equation)
+	if !ok {
+		return nil, errors.Join(ErrInvalidEquation, errors.New("an equation must contain '='"))
+	}
+	var vars []string
+	for _, v := range expression.Variables(eq) {
+		if !scope.Has(v) {
+			vars = append(vars, v)
+		}
+	}
+	if len(vars) != 1 {
+		return nil, errors.Join(ErrInvalidEquation, fmt.Errorf("one unknown excepted, got %d", len(vars)))
+	}
+	x := vars[0]
+	f := eq.Left
+	if z, err := eq.Right.Eval(scope); err != nil || !z.IsNull() {
+		f = expression.Sub(eq.Left, eq.Right)
+	}
+	coefs, ok, err := expression.Coefficients(f, x, scope)
+	if err != nil {
+		return nil, err
+	}
+	if ok && len(coefs) <= 3 {
+		if len(coefs) == 1 {
+			if coefs[0].IsNull() {
+				return &math.RealSet{}, nil
+			}
+			return &math.FiniteSet{}, nil
+		}
+		roots, err := polynomialRoots(coefs)
+		if err != nil {
+			return nil, err
+		}
+		sortRoots(roots)
+		return &math.FiniteSet{Values: roots}, nil
+	}
+	if p, ok := rationalPolynomial(coefs, x); ok {
+		return solveRationalPolynomial(p)
+	}
+	return solveNumeric(f, x, scope.Snapshot())
+}
+
+// rationalPolynomial returns the math.Polynomial having the coefficients if they are all rational
+func rationalPolynomial(coefs []*math.Complex, x string) (*math.Polynomial, bool) {
+	fracs := make([]*math.Fraction, len(coefs))
+	for i, c := range coefs {
+		f, ok := c.Fraction()
+		if !ok {
+			return nil, false
+		}
+		fracs[i] = f
+	}
+	return math.NewPolynomial(x, fracs...), len(fracs) > 0
+}
+
+// solveRationalPolynomial returns the roots of the factors of p: the roots of the factors of degree 2 or less are exact
+// and the real roots of the other ones are isolated with Sturm's theorem
+func solveRationalPolynomial(p *math.Polynomial) (math.Space, error) {
+	var roots []*math.Complex
+	_, factors := p.Factor()
+	for _, pf := range factors {
+		f := pf.Factor
+		if f.Degree() <= 2 {
+			coefs := make([]*math.Complex, f.Degree()+1)
+			for i := range coefs {
+				coefs[i] = math.FractionToComplex(f.Coefficient(i))
+			}
+			r, err := polynomialRoots(coefs)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range r {
+				roots = appendRoot(roots, v)
+			}
+			continue
+		}
+		eval := func(v *math.Fraction) (*math.Real, error) {
+			return math.FractionToReal(f.Eval(v)), nil
+		}
+		for _, in := range f.RealRootIntervals() {
+			name, latex := rootNames(f.String(), f.LaTeX(), f.Variable(), in[0], in[1])
+			r, err := math.NewRoot(eval, in[0], in[1], name, name, latex)
+			if err != nil {
+				return nil, err
+			}
+			roots = appendRoot(roots, math.RealToComplex(r))
+		}
+	}
+	sortRoots(roots)
+	return &math.FiniteSet{Values: roots}, nil
+}
+
+// rootNames returns the representations of the root of f between a and b, like rootof(cos(x) - x, x, 11/16, 3/4)
+func rootNames(f, latex, x string, a, b *math.Fraction) (string, string) {
+	return fmt.Sprintf("rootof(%s, %s, %s, %s)", f, x, a, b), fmt.Sprintf(
+		`\operatorname{rootof}\left(%s, %s, %s, %s\right)`,
+		latex, x, math.FractionToReal(a).LaTeX(), math.FractionToReal(b).LaTeX(),
+	)
+}
+
+// polynomialRoots returns the exact roots of the polynomial of degree 1 or 2
+func polynomialRoots(coefs []*math.Complex) ([]*math.Complex, error) {
+	var roots []*math.Complex
+	switch len(coefs) {
+	case 2:
+		// bx + c = 0
+		r, err := coefs[0].Neg().Div(coefs[1])
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, r)
+	case 3:
+		// ax^2 + bx + c = 0
+		a, b, c := coefs[2], coefs[1], coefs[0]
+		disc := b.Mul(b).Sub(math.IntToComplex(4).Mul(a).Mul(c))
+		twoA := math.IntToComplex(2).Mul(a)
+		sqrt, err := math.SqrtComplex(disc)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range []*math.Complex{sqrt.Neg(), sqrt} {
+			r, err := b.Neg().Add(s).Div(twoA)
+			if err != nil {
+				return nil, err
+			}
+			roots = appendRoot(roots, r)
+		}
+	}
+	return roots, nil
+}
+
+// solveNumeric returns the real roots of f(x) = 0 found with a sign change between two sampled points.
+// The other variables are evaluated in the given Scope.
+// Returns ErrNoRootFound if no root is found, since the equation may have roots outside of the sampled interval or
+// roots where f does not change its sign.
+func solveNumeric(f expression.Expression, x string, scope *expression.Scope) (math.Space, error) {
+	evalReal := func(v *math.Real) (*math.Real, error) {
+		s := expression.NewScope(scope)
+		s.SetReal(x, v)
+		z, err := f.Eval(s)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := z.Real()
+		if !ok {
+			return nil, errors.Join(expression.ErrNumberNotInSpace, fmt.Errorf("%s is not real", z))
+		}
+		return r, nil
+	}
+	eval := func(v *math.Fraction) (*math.Real, error) {
+		return evalReal(math.FractionToReal(v))
+	}
+	var roots []*math.Complex
+	pts := samplePoints()
+	values := make([]*math.Real, len(pts))
+	for i, p := range pts {
+		values[i], _ = eval(p)
+	}
+	for i, p := range pts {
+		fa := values[i]
+		if fa == nil {
+			continue
+		}
+		if fa.IsNull() {
+			if i > 0 && values[i-1] != nil && values[i-1].IsNull() {
+				return nil, errors.Join(
+					ErrInvalidEquation,
+					fmt.Errorf("%s = 0 is true between %s and %s: it has infinitely many solutions", expression.String(f), pts[i-1], p),
+				)
+			}
+			roots = appendRoot(roots, math.FractionToComplex(p))
+			continue
+		}
+		if i == len(pts)-1 || values[i+1] == nil || values[i+1].IsNull() || fa.Sign() == values[i+1].Sign() {
+			continue
+		}
+		r, ok, err := findRoot(f, x, scope, eval, p, pts[i+1], fa, values[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			roots = appendRoot(roots, r)
+		}
+	}
+	if len(roots) > 0 && isPeriodic(evalReal, scope.AngleUnit().Period()) {
+		return nil, errors.Join(
+			ErrInvalidEquation,
+			fmt.Errorf("%s is periodic: the equation has infinitely many solutions", expression.String(f)),
+		)
+	}
+	if len(roots) == 0 {
+		return nil, errors.Join(
+			ErrNoRootFound,
+			fmt.Errorf("%s = 0 has no root where it changes its sign between %d and %d", expression.String(f), -searchBound, searchBound),
+		)
+	}
+	sortRoots(roots)
+	return &math.FiniteSet{Values: roots}, nil
+}
+
+// findRoot returns the root of f between a and b, f(a) and f(b) having opposite signs.
+// The root is exact if it is a rational number with a small denominator, otherwise it is computed by math.NewRoot.
+// Returns false if the sign change is caused by a discontinuity, like 1/x around 0.
+func findRoot(
+	f expression.Expression,
+	x string,
+	scope *expression.Scope,
+	eval math.RealFunction,
+	a, b *math.Fraction,
+	fa, fb *math.Real,
+) (*math.Complex, bool, error) {
+	two := math.IntToFraction(2)
+	bound := min(abs(fa.Float()), abs(fb.Float()))
+	signA := fa.Sign()
+	l, u := a, b
+	for range maxBisection {
+		m, _ := l.Add(u).Div(two)
+		fm, err := eval(m)
+		if err != nil {
+			return nil, false, nil
+		}
+		if fm.IsNull() {
+			return math.FractionToComplex(m), true, nil
+		}
+		if fm.Sign() == signA {
+			l = m
+		} else {
+			u = m
+		}
+	}
+	m, _ := l.Add(u).Div(two)
+	fm, err := eval(m)
+	if err != nil || abs(fm.Float()) > bound {
+		return nil, false, nil
+	}
+	if q := closeFraction(m); q != nil {
+		if fq, err := eval(q); err == nil && fq.IsNull() {
+			return math.FractionToComplex(q), true, nil
+		}
+	}
+	latex, _, err := f.RenderLatex()
+	if err != nil {
+		return nil, false, err
+	}
+	name, latex := rootNames(expression.String(f), latex, x, a, b)
+	// the root depends on the values of the other variables and on the angle unit
+	id := name + "[" + scope.AngleUnit().String()
+	for _, v := range expression.Variables(f) {
+		if v == x {
+			continue
+		}
+		val, err := scope.Eval(v)
+		if err != nil {
+			return nil, false, err
+		}
+		id += fmt.Sprintf(";%s=%s", v, val)
+	}
+	r, err := math.NewRoot(eval, l, u, id+"]", name, latex)
+	if err != nil {
+		return nil, false, err
+	}
+	return math.RealToComplex(r), true, nil
+}
+
+// isPeriodic returns true if f(x + period) = f(x) for a few points x where f is defined.
+// The values are compared with periodPrecision digits.
+func isPeriodic(eval func(*math.Real) (*math.Real, error), period *math.Complex) bool {
+	t, ok := period.Real()
+	if !ok {
+		return false
+	}
+	checked := 0
+	for _, p := range []*math.Fraction{math.NewFraction(1, 3), math.NewFraction(-7, 5), math.NewFraction(13, 4)} {
+		v, err := eval(math.FractionToReal(p))
+		if err != nil {
+			continue
+		}
+		shifted, err := eval(math.FractionToReal(p).Add(t))
+		if err != nil {
+			return false
+		}
+		if v.Approx(periodPrecision) != shifted.Approx(periodPrecision) {
+			return false
+		}
+		checked++
+	}
+	return checked > 0
+}
+
+// samplePoints returns the points where the function is evaluated to find its roots, in [-searchBound ; searchBound].
+// They are denser in [-10 ; 10].
+func samplePoints() []*math.Fraction {
+	var positives []*math.Fraction
+	for j := int64(1); j <= 160; j++ {
+		positives = append(positives, math.NewFraction(j, 16))
+	}
+	for j := int64(41); j <= 4*searchBound; j++ {
+		positives = append(positives, math.NewFraction(j, 4))
+	}
+	pts := make([]*math.Fraction, 0, 2*len(positives)+1)
+	for i := len(positives) - 1; i >= 0; i-- {
+		pts = append(pts, positives[i].Neg())
+	}
+	pts = append(pts, math.NullFraction)
+	return append(pts, positives...)
+}
+
+// closeFraction returns the fraction with a denominator smaller than maxSnapDenominator closest to f, by using its
+// continued fraction.
+// Returns nil if there is no such fraction near f.
+func closeFraction(f *math.Fraction) *math.Fraction {
+	h0, h1 := big.NewInt(0), big.NewInt(1)
+	k0, k1 := big.NewInt(1), big.NewInt(0)
+	x := new(big.Rat).Set(f.Rat)
+	maxDen := big.NewInt(maxSnapDenominator)
+	var best *big.Rat
+	for range 32 {
+		a := new(big.Int).Div(x.Num(), x.Denom())
+		h0, h1 = h1, new(big.Int).Add(new(big.Int).Mul(a, h1), h0)
+		k0, k1 = k1, new(big.Int).Add(new(big.Int).Mul(a, k1), k0)
+		if k1.Cmp(maxDen) > 0 {
+			break
+		}
+		best = new(big.Rat).SetFrac(h1, k1)
+		x.Sub(x, new(big.Rat).SetInt(a))
+		if x.Sign() == 0 {
+			break
+		}
+		x.Inv(x)
+	}
+	if best == nil {
+		return nil
+	}
+	return &math.Fraction{Rat: best}
+}
+
+// appendRoot appends r to roots if it is not already in it
+func appendRoot(roots []*math.Complex, r *math.Complex) []*math.Complex {
+	for _, v := range roots {
+		if v.Is(r) {
+			return roots
+		}
+	}
+	return append(roots, r)
+}
+
+// sortRoots sorts the roots by their real part, then by their imaginary part
+func sortRoots(roots []*math.Complex) {
+	slices.SortFunc(roots, func(a, b *math.Complex) int {
+		if c := a.Re().Cmp(b.Re()); c != 0 {
+			return c
+		}
+		return a.Im().Cmp(b.Im())
+	})
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,139 @@
+package gomath
+
+import (
+	"errors"
+	"github.com/nyttikord/gomath/ast"
+	"github.com/nyttikord/gomath/expression"
+	"github.com/nyttikord/gomath/math"
+	"testing"
+)
+
+func genericTestSolve(t *testing.T, equation string, expected string) {
+	sol, err := Solve(equation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.String() != expected {
+		t.Errorf("%s: got %s; want %s", equation, sol, expected)
+	}
+}
+
+func TestSolve_Exact(t *testing.T) {
+	genericTestSolve(t, "2x+3=7", "{ 2 }")
+	genericTestSolve(t, "x/2 + 1/3 = 0", "{ -2/3 }")
+	genericTestSolve(t, "x^2=2", "{ -sqrt(2) ; sqrt(2) }")
+	genericTestSolve(t, "(x+1)(x-2)=0", "{ -1 ; 2 }")
+	genericTestSolve(t, "x^2-2x+1=0", "{ 1 }")
+	genericTestSolve(t, "x^2+1=0", "{ -i ; i }")
+	genericTestSolve(t, "pi*y = 1", "{ 1/pi }")
+	genericTestSolve(t, "x+1=x", "∅")
+	genericTestSolve(t, "2x=x+x", "R")
+	// polynomials with rational coefficients are factored
+	genericTestSolve(t, "(x - 1/3)^4 = 0", "{ 1/3 }")
+	genericTestSolve(t, "x^4 = 1", "{ -1 ; -i ; i ; 1 }")
+	genericTestSolve(t, "x^3-6x^2+11x-6=0", "{ 1 ; 2 ; 3 }")
+}
+
+func TestSolve_Polynomial(t *testing.T) {
+	genericTestSolve(t, "x^3 = 2", "{ rootof(x^3 - 2, x, -3, 3) }")
+	genericTestSolve(t, "x^5 - x - 1 = 0", "{ rootof(x^5 - x - 1, x, -2, 2) }")
+
+	sol, err := Solve("(x^3 - 2)(x - 1/3)^2 = 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, ok := sol.(*math.FiniteSet)
+	if !ok {
+		t.Fatalf("%s is not a finite set", sol)
+	}
+	if got, expected := set.Approx(10), "{ 0.3333333333 ; 1.2599210499 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+}
+
+func TestSolve_Numeric(t *testing.T) {
+	genericTestSolve(t, "sqrt(x)=3", "{ 9 }")
+
+	sol, err := Solve("cos(x)=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, ok := sol.(*math.FiniteSet)
+	if !ok {
+		t.Fatalf("%s is not a finite set", sol)
+	}
+	if got, expected := set.Approx(10), "{ 0.7390851332 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+	// the numeric roots are approximated with the precision requested
+	if got, expected := set.Approx(40), "{ 0.7390851332151606416553120876738734040134 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+	if r, _ := set.Values[0].Real(); r.IsRational() {
+		t.Errorf("%s must not be exact", set.Values[0])
+	}
+	if got, expected := set.String(), "{ rootof(cos(x) - x, x, 11/16, 3/4) }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+}
+
+func TestSolve_Options(t *testing.T) {
+	reg := expression.DefaultRegistry.Clone()
+	err := reg.SetFunction("vat", &math.RealSet{}, func(r *math.Real) (*math.Real, error) {
+		return r.Mul(math.FractionToReal(math.NewFraction(6, 5))), nil
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sol, err := Solve("vat(x)^3 = 27", &ast.Options{Registry: reg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := sol.String(), "{ 5/2 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+
+	scope := expression.NewScope(nil)
+	scope.SetFraction("k", math.IntToFraction(8))
+	sol, err = Solve("x^3 = k", &ast.Options{Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := sol.String(), "{ -1 - sqrt(3)i ; -1 + sqrt(3)i ; 2 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+
+	sol, err = Solve("sin(x) = x/60", &ast.Options{Angle: expression.Degree})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := sol.String(), "{ -30 ; 0 ; 30 }"; got != expected {
+		t.Errorf("got %s; want %s", got, expected)
+	}
+}
+
+func TestSolve_Errors(t *testing.T) {
+	if _, err := Solve("x+1"); !errors.Is(err, ErrInvalidEquation) {
+		t.Errorf("expected invalid equation error, not %v", err)
+	}
+	if _, err := Solve("x+y=1"); !errors.Is(err, ErrInvalidEquation) {
+		t.Errorf("expected invalid equation error, not %v", err)
+	}
+	// infinitely many solutions
+	if _, err := Solve("x/x = 1"); !errors.Is(err, ErrInvalidEquation) {
+		t.Errorf("expected invalid equation error, not %v", err)
+	}
+	if _, err := Solve("sin(x) = 1/2"); !errors.Is(err, ErrInvalidEquation) {
+		t.Errorf("expected invalid equation error, not %v", err)
+	}
+	if _, err := Solve("sin(x) = 1/2", &ast.Options{Angle: expression.Degree}); !errors.Is(err, ErrInvalidEquation) {
+		t.Errorf("expected invalid equation error, not %v", err)
+	}
+	// no root found by the numeric search
+	if _, err := Solve("1/x = 0"); !errors.Is(err, ErrNoRootFound) {
+		t.Errorf("expected no root found error, not %v", err)
+	}
+	if _, err := Solve("exp(x) = 1e60"); !errors.Is(err, ErrNoRootFound) {
+		t.Errorf("expected no root found error, not %v", err)
+	}
+}